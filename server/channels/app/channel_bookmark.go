@@ -0,0 +1,254 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+	"github.com/mattermost/mattermost/server/v8/channels/app/request"
+)
+
+const channelBookmarkPageDefaultLimit = 60
+
+// CreateChannelBookmark pins a new link or file bookmark to a channel,
+// visible to every member.
+func (a *App) CreateChannelBookmark(c request.CTX, newBookmark *model.ChannelBookmark, connectionId string) (*model.ChannelBookmarkWithFileInfo, *model.AppError) {
+	if _, appErr := a.GetChannel(c, newBookmark.ChannelId); appErr != nil {
+		return nil, appErr
+	}
+
+	newBookmark.OwnerId = c.Session().UserId
+	newBookmark.PreSave()
+
+	if appErr := newBookmark.IsValid(); appErr != nil {
+		return nil, appErr
+	}
+
+	if newBookmark.Type == model.ChannelBookmarkFile {
+		if _, err := a.Srv().Store().FileInfo().Get(newBookmark.FileId); err != nil {
+			return nil, model.NewAppError("CreateChannelBookmark", "app.channel_bookmark.create.file_not_found.app_error", nil, "", http.StatusBadRequest).Wrap(err)
+		}
+	}
+
+	saved, err := a.Srv().Store().ChannelBookmark().Save(newBookmark)
+	if err != nil {
+		return nil, model.NewAppError("CreateChannelBookmark", "app.channel_bookmark.create.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	result := &model.ChannelBookmarkWithFileInfo{ChannelBookmark: saved}
+	a.publishChannelBookmarkEvent(model.WebsocketEventChannelBookmarkCreated, result, connectionId)
+
+	if saved.Type == model.ChannelBookmarkLink && saved.ImageUrl == "" {
+		a.enrichChannelBookmarkLinkAsync(saved.Clone())
+	}
+
+	return result, nil
+}
+
+// UpdateChannelBookmark edits an existing bookmark. When the editor isn't
+// the owner, the original is soft-deleted and a new bookmark is forked under
+// the editor's ownership rather than mutating someone else's row in place.
+func (a *App) UpdateChannelBookmark(c request.CTX, patch *model.ChannelBookmark, connectionId string) (*model.UpdateChannelBookmarkResponse, *model.AppError) {
+	existing, err := a.Srv().Store().ChannelBookmark().Get(patch.Id, false)
+	if err != nil {
+		return nil, model.NewAppError("UpdateChannelBookmark", "app.channel_bookmark.update.not_found.app_error", nil, "", http.StatusNotFound).Wrap(err)
+	}
+
+	if existing.DeleteAt > 0 {
+		return nil, model.NewAppError("UpdateChannelBookmark", "app.channel_bookmark.update.deleted.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if existing.OwnerId != c.Session().UserId {
+		return a.forkChannelBookmark(existing, patch, c.Session().UserId, connectionId)
+	}
+
+	patch.PreUpdate()
+	if appErr := patch.IsValid(); appErr != nil {
+		return nil, appErr
+	}
+
+	if err := a.Srv().Store().ChannelBookmark().Update(patch); err != nil {
+		return nil, model.NewAppError("UpdateChannelBookmark", "app.channel_bookmark.update.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	response := &model.UpdateChannelBookmarkResponse{Updated: &model.ChannelBookmarkWithFileInfo{ChannelBookmark: patch}}
+	a.publishChannelBookmarkEvent(model.WebsocketEventChannelBookmarkUpdated, response.Updated, connectionId)
+
+	return response, nil
+}
+
+func (a *App) forkChannelBookmark(existing *model.ChannelBookmark, patch *model.ChannelBookmark, editorId, connectionId string) (*model.UpdateChannelBookmarkResponse, *model.AppError) {
+	if err := a.Srv().Store().ChannelBookmark().Delete(existing.Id); err != nil {
+		return nil, model.NewAppError("UpdateChannelBookmark", "app.channel_bookmark.update.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	deleted := existing.Clone()
+	deleted.DeleteAt = model.GetMillis()
+
+	forked := patch.Clone()
+	forked.Id = ""
+	forked.OriginalId = existing.Id
+	forked.OwnerId = editorId
+	forked.PreSave()
+
+	if appErr := forked.IsValid(); appErr != nil {
+		return nil, appErr
+	}
+
+	saved, err := a.Srv().Store().ChannelBookmark().Save(forked)
+	if err != nil {
+		return nil, model.NewAppError("UpdateChannelBookmark", "app.channel_bookmark.update.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	response := &model.UpdateChannelBookmarkResponse{
+		Updated: &model.ChannelBookmarkWithFileInfo{ChannelBookmark: saved},
+		Deleted: &model.ChannelBookmarkWithFileInfo{ChannelBookmark: deleted},
+	}
+	a.publishChannelBookmarkEvent(model.WebsocketEventChannelBookmarkUpdated, response.Updated, connectionId)
+	a.publishChannelBookmarkEvent(model.WebsocketEventChannelBookmarkDeleted, response.Deleted, connectionId)
+
+	return response, nil
+}
+
+// DeleteChannelBookmark soft-deletes a channel bookmark.
+func (a *App) DeleteChannelBookmark(id, connectionId string) (*model.ChannelBookmarkWithFileInfo, *model.AppError) {
+	bookmark, err := a.Srv().Store().ChannelBookmark().Get(id, false)
+	if err != nil {
+		return nil, model.NewAppError("DeleteChannelBookmark", "app.channel_bookmark.delete.not_found.app_error", nil, "", http.StatusNotFound).Wrap(err)
+	}
+
+	if err := a.Srv().Store().ChannelBookmark().Delete(id); err != nil {
+		return nil, model.NewAppError("DeleteChannelBookmark", "app.channel_bookmark.delete.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	bookmark.DeleteAt = model.GetMillis()
+	result := &model.ChannelBookmarkWithFileInfo{ChannelBookmark: bookmark}
+	a.publishChannelBookmarkEvent(model.WebsocketEventChannelBookmarkDeleted, result, connectionId)
+
+	return result, nil
+}
+
+// GetChannelBookmarksPage is the cursor-based primitive for listing a
+// channel's bookmarks, ordered by (sort_order, id) so pages stay stable
+// across concurrent inserts.
+func (a *App) GetChannelBookmarksPage(query model.ChannelBookmarkQuery) (*model.ChannelBookmarkPage, *model.AppError) {
+	if query.Limit <= 0 || query.Limit > 200 {
+		query.Limit = channelBookmarkPageDefaultLimit
+	}
+
+	page, err := a.Srv().Store().ChannelBookmark().GetPage(query)
+	if err != nil {
+		return nil, model.NewAppError("GetChannelBookmarksPage", "app.channel_bookmark.get.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	return page, nil
+}
+
+// GetChannelBookmarks is a delta-sync convenience wrapper over
+// GetChannelBookmarksPage kept for existing callers: since == 0 returns
+// every live bookmark, since > 0 returns only what changed (including
+// soft-deletes) at or after that time. It exhausts every page rather than
+// capping at GetChannelBookmarksPage's default page size, so callers never
+// see bookmarks silently dropped past the first channelBookmarkPageDefaultLimit rows.
+func (a *App) GetChannelBookmarks(channelId string, since int64) ([]*model.ChannelBookmarkWithFileInfo, *model.AppError) {
+	var all []*model.ChannelBookmarkWithFileInfo
+	cursor := ""
+
+	for {
+		page, appErr := a.GetChannelBookmarksPage(model.ChannelBookmarkQuery{
+			ChannelId:      channelId,
+			Since:          since,
+			Cursor:         cursor,
+			IncludeDeleted: since > 0,
+		})
+		if appErr != nil {
+			return nil, appErr
+		}
+
+		all = append(all, page.Items...)
+
+		if !page.HasMore || page.NextCursor == "" {
+			break
+		}
+		cursor = page.NextCursor
+	}
+
+	return all, nil
+}
+
+// GetAllChannelBookmarks returns GetChannelBookmarks for each channel,
+// omitting channels with nothing to report so delta-sync payloads stay
+// small.
+func (a *App) GetAllChannelBookmarks(channelIds []string, since int64) (map[string][]*model.ChannelBookmarkWithFileInfo, *model.AppError) {
+	result := make(map[string][]*model.ChannelBookmarkWithFileInfo)
+
+	for _, channelId := range channelIds {
+		items, appErr := a.GetChannelBookmarks(channelId, since)
+		if appErr != nil {
+			return nil, appErr
+		}
+
+		if len(items) > 0 {
+			result[channelId] = items
+		}
+	}
+
+	return result, nil
+}
+
+// UpdateChannelBookmarkSortOrder moves a bookmark to newIndex among its
+// channel siblings (0-based), renumbering everyone between the old and new
+// position.
+func (a *App) UpdateChannelBookmarkSortOrder(bookmarkId, channelId string, newIndex int64, connectionId string) ([]*model.ChannelBookmarkWithFileInfo, *model.AppError) {
+	existing, err := a.Srv().Store().ChannelBookmark().Get(bookmarkId, false)
+	if err != nil {
+		return nil, model.NewAppError("UpdateChannelBookmarkSortOrder", "app.channel_bookmark.sort_order.not_found.app_error", nil, "", http.StatusNotFound).Wrap(err)
+	}
+
+	siblings, err := a.Srv().Store().ChannelBookmark().GetBookmarksForChannelSince(channelId, 0)
+	if err != nil {
+		return nil, model.NewAppError("UpdateChannelBookmarkSortOrder", "app.channel_bookmark.sort_order.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	if newIndex < 0 || newIndex >= int64(len(siblings)) {
+		return nil, model.NewAppError("UpdateChannelBookmarkSortOrder", "app.channel_bookmark.sort_order.out_of_bounds.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	updated, err := a.Srv().Store().ChannelBookmark().UpdateSortOrder(existing.Id, channelId, newIndex)
+	if err != nil {
+		return nil, model.NewAppError("UpdateChannelBookmarkSortOrder", "app.channel_bookmark.sort_order.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	result := make([]*model.ChannelBookmarkWithFileInfo, 0, len(updated))
+	for _, b := range updated {
+		result = append(result, &model.ChannelBookmarkWithFileInfo{ChannelBookmark: b})
+	}
+
+	a.publishChannelBookmarkSortOrderEvent(channelId, result, connectionId)
+
+	return result, nil
+}
+
+func (a *App) publishChannelBookmarkSortOrderEvent(channelId string, bookmarks []*model.ChannelBookmarkWithFileInfo, connectionId string) {
+	message := model.NewWebSocketEvent(model.WebsocketEventChannelBookmarkSorted, "", channelId, "", nil, connectionId)
+	bookmarksJSON, err := json.Marshal(bookmarks)
+	if err != nil {
+		mlog.Warn("Failed to encode channel bookmarks to JSON", mlog.Err(err))
+	}
+	message.Add("bookmarks", string(bookmarksJSON))
+	a.Publish(message)
+}
+
+func (a *App) publishChannelBookmarkEvent(event string, bookmark *model.ChannelBookmarkWithFileInfo, connectionId string) {
+	message := model.NewWebSocketEvent(event, "", bookmark.ChannelId, "", nil, connectionId)
+	bookmarkJSON, err := json.Marshal(bookmark)
+	if err != nil {
+		mlog.Warn("Failed to encode channel bookmark to JSON", mlog.Err(err))
+	}
+	message.Add("bookmark", string(bookmarkJSON))
+	a.Publish(message)
+}
@@ -0,0 +1,281 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"container/list"
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"regexp"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"golang.org/x/sync/singleflight"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+)
+
+const (
+	linkEnrichmentCacheSize = 256
+	linkEnrichmentTimeout   = 5 * time.Second
+	linkEnrichmentMaxBody   = 1 << 20 // 1MB, plenty for the <head> of a normal page
+)
+
+var (
+	linkEnrichmentGroup singleflight.Group
+	linkEnrichmentCache = newLinkMetadataCache(linkEnrichmentCacheSize)
+
+	channelBookmarkEnrichmentDisabledVar             atomic.Bool
+	channelBookmarkEnrichmentAllowPrivateNetworksVar atomic.Bool
+)
+
+// SetChannelBookmarkEnrichmentDisabled turns the async link-preview
+// enrichment pipeline on CreateChannelBookmark on or off for the running
+// process. This tree has no config-reload plumbing for app-layer features
+// to hook into, so unlike a real config setting this has no effect until
+// something calls it directly (today: tests only) — it is not wired to any
+// config key.
+func SetChannelBookmarkEnrichmentDisabled(disabled bool) {
+	channelBookmarkEnrichmentDisabledVar.Store(disabled)
+}
+
+// SetChannelBookmarkEnrichmentAllowPrivateNetworks controls whether the
+// link-preview fetcher is allowed to dial loopback/private/link-local
+// addresses. It defaults to false (blocked) so a channel member can't use a
+// bookmark URL to make the server fetch http://169.254.169.254/... or an
+// internal http://10.x.x.x service and have the scraped response published
+// to the whole channel; it exists as a setting (rather than being
+// hardcoded) for admins running Mattermost against internal link-preview
+// targets in their own network, and for tests that stand up a local server.
+func SetChannelBookmarkEnrichmentAllowPrivateNetworks(allow bool) {
+	channelBookmarkEnrichmentAllowPrivateNetworksVar.Store(allow)
+}
+
+type linkMetadataCacheEntry struct {
+	url      string
+	metadata *model.LinkMetadata
+}
+
+// linkMetadataCache is a small fixed-size LRU so bursts of the same URL
+// (e.g. several people bookmarking the same link around the same time)
+// only fetch once.
+type linkMetadataCache struct {
+	mu      sync.Mutex
+	size    int
+	entries map[string]*list.Element
+	order   *list.List
+}
+
+func newLinkMetadataCache(size int) *linkMetadataCache {
+	return &linkMetadataCache{
+		size:    size,
+		entries: make(map[string]*list.Element),
+		order:   list.New(),
+	}
+}
+
+func (c *linkMetadataCache) Get(url string) (*model.LinkMetadata, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	el, ok := c.entries[url]
+	if !ok {
+		return nil, false
+	}
+
+	c.order.MoveToFront(el)
+	return el.Value.(*linkMetadataCacheEntry).metadata, true
+}
+
+func (c *linkMetadataCache) Add(url string, metadata *model.LinkMetadata) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if el, ok := c.entries[url]; ok {
+		el.Value.(*linkMetadataCacheEntry).metadata = metadata
+		c.order.MoveToFront(el)
+		return
+	}
+
+	el := c.order.PushFront(&linkMetadataCacheEntry{url: url, metadata: metadata})
+	c.entries[url] = el
+
+	if c.order.Len() > c.size {
+		if oldest := c.order.Back(); oldest != nil {
+			c.order.Remove(oldest)
+			delete(c.entries, oldest.Value.(*linkMetadataCacheEntry).url)
+		}
+	}
+}
+
+var (
+	ogTitleRe       = regexp.MustCompile(`(?i)<meta[^>]+property=["']og:title["'][^>]+content=["']([^"']*)["']`)
+	ogDescriptionRe = regexp.MustCompile(`(?i)<meta[^>]+property=["']og:description["'][^>]+content=["']([^"']*)["']`)
+	ogImageRe       = regexp.MustCompile(`(?i)<meta[^>]+property=["']og:image["'][^>]+content=["']([^"']*)["']`)
+	canonicalRe     = regexp.MustCompile(`(?i)<link[^>]+rel=["']canonical["'][^>]+href=["']([^"']*)["']`)
+	faviconRe       = regexp.MustCompile(`(?i)<link[^>]+rel=["'](?:shortcut )?icon["'][^>]+href=["']([^"']*)["']`)
+)
+
+// parseLinkMetadata extracts OpenGraph and canonical/favicon hints from an
+// HTML document. It's intentionally a light regex scan of the raw bytes
+// rather than a full parse, since only a handful of <head> tags matter here.
+func parseLinkMetadata(rawURL string, body []byte) (*model.LinkMetadata, string) {
+	metadata := &model.LinkMetadata{CanonicalUrl: rawURL}
+	var imageUrl string
+
+	if m := ogTitleRe.FindSubmatch(body); m != nil {
+		metadata.Title = string(m[1])
+	}
+	if m := ogDescriptionRe.FindSubmatch(body); m != nil {
+		metadata.Description = string(m[1])
+	}
+	if m := ogImageRe.FindSubmatch(body); m != nil {
+		imageUrl = string(m[1])
+	}
+	if m := canonicalRe.FindSubmatch(body); m != nil {
+		metadata.CanonicalUrl = string(m[1])
+	}
+	if m := faviconRe.FindSubmatch(body); m != nil {
+		metadata.FaviconUrl = string(m[1])
+	}
+
+	return metadata, imageUrl
+}
+
+// checkLinkEnrichmentHost rejects schemes other than http/https and, unless
+// SetChannelBookmarkEnrichmentAllowPrivateNetworks(true) was called, any
+// hostname that resolves to a loopback/private/link-local/unspecified
+// address — so a bookmarked http://169.254.169.254/... or internal
+// http://10.x.x.x URL never gets dialed and its response scraped and
+// published to the channel.
+func checkLinkEnrichmentHost(rawURL string) error {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return fmt.Errorf("invalid link preview URL: %w", err)
+	}
+
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return fmt.Errorf("unsupported link preview scheme %q", u.Scheme)
+	}
+
+	host := u.Hostname()
+	if host == "" {
+		return fmt.Errorf("missing link preview host")
+	}
+
+	if channelBookmarkEnrichmentAllowPrivateNetworksVar.Load() {
+		return nil
+	}
+
+	ips, err := net.LookupIP(host)
+	if err != nil {
+		return fmt.Errorf("failed to resolve link preview host: %w", err)
+	}
+
+	for _, ip := range ips {
+		if isReservedLinkEnrichmentIP(ip) {
+			return fmt.Errorf("refusing to fetch link preview for reserved address %s", ip)
+		}
+	}
+
+	return nil
+}
+
+func isReservedLinkEnrichmentIP(ip net.IP) bool {
+	return ip.IsLoopback() ||
+		ip.IsPrivate() ||
+		ip.IsLinkLocalUnicast() ||
+		ip.IsLinkLocalMulticast() ||
+		ip.IsUnspecified() ||
+		ip.IsMulticast()
+}
+
+// fetchLinkMetadata resolves rawURL's link preview metadata, deduplicating
+// concurrent requests for the same URL via a singleflight group and caching
+// the result in a small LRU.
+func (a *App) fetchLinkMetadata(rawURL string) (*model.LinkMetadata, string, error) {
+	if cached, ok := linkEnrichmentCache.Get(rawURL); ok {
+		return cached, "", nil
+	}
+
+	if err := checkLinkEnrichmentHost(rawURL); err != nil {
+		return nil, "", err
+	}
+
+	type fetchResult struct {
+		metadata *model.LinkMetadata
+		imageUrl string
+	}
+
+	v, err, _ := linkEnrichmentGroup.Do(rawURL, func() (any, error) {
+		ctx, cancel := context.WithTimeout(context.Background(), linkEnrichmentTimeout)
+		defer cancel()
+
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, rawURL, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			return nil, err
+		}
+		defer resp.Body.Close()
+
+		body, err := io.ReadAll(io.LimitReader(resp.Body, linkEnrichmentMaxBody))
+		if err != nil {
+			return nil, err
+		}
+
+		metadata, imageUrl := parseLinkMetadata(rawURL, body)
+		linkEnrichmentCache.Add(rawURL, metadata)
+
+		return fetchResult{metadata: metadata, imageUrl: imageUrl}, nil
+	})
+	if err != nil {
+		return nil, "", err
+	}
+
+	r := v.(fetchResult)
+	return r.metadata, r.imageUrl, nil
+}
+
+// enrichChannelBookmarkLinkAsync resolves OpenGraph metadata for a
+// just-created link bookmark and writes it back, emitting a follow-up
+// channel_bookmark_updated event. It updates the store directly rather than
+// going through App.UpdateChannelBookmark so a background enrichment never
+// triggers that method's "someone else edited it" fork logic.
+func (a *App) enrichChannelBookmarkLinkAsync(bookmark *model.ChannelBookmark) {
+	if channelBookmarkEnrichmentDisabledVar.Load() {
+		return
+	}
+
+	a.Srv().Go(func() {
+		metadata, imageUrl, err := a.fetchLinkMetadata(bookmark.LinkUrl)
+		if err != nil {
+			mlog.Warn("Failed to enrich channel bookmark link", mlog.String("url", bookmark.LinkUrl), mlog.Err(err))
+			return
+		}
+
+		patch := bookmark.Clone()
+		patch.LinkMetadata = metadata
+		if imageUrl != "" {
+			patch.ImageUrl = a.ImageProxyURL(imageUrl)
+		}
+		patch.PreUpdate()
+
+		if err := a.Srv().Store().ChannelBookmark().Update(patch); err != nil {
+			mlog.Warn("Failed to persist channel bookmark link enrichment", mlog.String("bookmarkId", patch.Id), mlog.Err(err))
+			return
+		}
+
+		a.publishChannelBookmarkEvent(model.WebsocketEventChannelBookmarkUpdated, &model.ChannelBookmarkWithFileInfo{ChannelBookmark: patch}, "")
+	})
+}
@@ -0,0 +1,86 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseLinkMetadata(t *testing.T) {
+	body := []byte(`<html><head>
+		<meta property="og:title" content="Mattermost">
+		<meta property="og:description" content="Open source collaboration">
+		<meta property="og:image" content="https://example.com/og.png">
+		<link rel="canonical" href="https://mattermost.com/">
+		<link rel="icon" href="https://mattermost.com/favicon.ico">
+	</head></html>`)
+
+	metadata, imageUrl := parseLinkMetadata("https://mattermost.com", body)
+
+	assert.Equal(t, "Mattermost", metadata.Title)
+	assert.Equal(t, "Open source collaboration", metadata.Description)
+	assert.Equal(t, "https://mattermost.com/", metadata.CanonicalUrl)
+	assert.Equal(t, "https://mattermost.com/favicon.ico", metadata.FaviconUrl)
+	assert.Equal(t, "https://example.com/og.png", imageUrl)
+}
+
+func TestFetchLinkMetadata(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`<meta property="og:title" content="Test Page">`))
+	}))
+	defer server.Close()
+
+	SetChannelBookmarkEnrichmentAllowPrivateNetworks(true)
+	defer SetChannelBookmarkEnrichmentAllowPrivateNetworks(false)
+
+	a := &App{}
+
+	metadata, _, err := a.fetchLinkMetadata(server.URL)
+	require.NoError(t, err)
+	assert.Equal(t, "Test Page", metadata.Title)
+
+	// A second fetch of the same URL should be served from cache rather
+	// than hitting the server again.
+	cached, _, err := a.fetchLinkMetadata(server.URL)
+	require.NoError(t, err)
+	assert.Equal(t, metadata.Title, cached.Title)
+}
+
+func TestFetchLinkMetadataBlocksPrivateNetworks(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`<meta property="og:title" content="Should never be scraped">`))
+	}))
+	defer server.Close()
+
+	a := &App{}
+
+	_, _, err := a.fetchLinkMetadata(server.URL)
+	require.Error(t, err)
+}
+
+func TestCheckLinkEnrichmentHost(t *testing.T) {
+	t.Run("rejects cloud metadata address", func(t *testing.T) {
+		require.Error(t, checkLinkEnrichmentHost("http://169.254.169.254/latest/meta-data/"))
+	})
+
+	t.Run("rejects unsupported scheme", func(t *testing.T) {
+		require.Error(t, checkLinkEnrichmentHost("file:///etc/passwd"))
+	})
+
+	t.Run("errors when the host can't be resolved", func(t *testing.T) {
+		require.Error(t, checkLinkEnrichmentHost("http://this-host-should-not-resolve.invalid"))
+	})
+
+	t.Run("the private-network override disables the check entirely", func(t *testing.T) {
+		SetChannelBookmarkEnrichmentAllowPrivateNetworks(true)
+		defer SetChannelBookmarkEnrichmentAllowPrivateNetworks(false)
+
+		require.NoError(t, checkLinkEnrichmentHost("http://169.254.169.254/latest/meta-data/"))
+	})
+}
@@ -0,0 +1,213 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"encoding/json"
+	"fmt"
+	"html"
+	"net/http"
+	"regexp"
+	"strings"
+
+	"github.com/mattermost/mattermost/server/public/model"
+)
+
+const (
+	BookmarkImportFormatJSON     = "json"
+	BookmarkImportFormatNetscape = "netscape"
+)
+
+// bookmarkImportRow is the intermediate representation both import formats
+// are parsed into before being turned into ChannelBookmarks.
+type bookmarkImportRow struct {
+	Title  string
+	URL    string
+	Folder string
+}
+
+type jsonBookmarkImportRow struct {
+	Title  string `json:"title"`
+	URL    string `json:"url"`
+	Folder string `json:"folder,omitempty"`
+}
+
+// ExportChannelBookmarks serializes a channel's live link bookmarks to the
+// JSON schema accepted back by ImportChannelBookmarks, for backup/round-trip
+// purposes.
+func (a *App) ExportChannelBookmarks(channelId string) ([]byte, error) {
+	bookmarks, appErr := a.GetChannelBookmarks(channelId, 0)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	rows := make([]jsonBookmarkImportRow, 0, len(bookmarks))
+	for _, b := range bookmarks {
+		if b.Type != model.ChannelBookmarkLink {
+			continue
+		}
+		rows = append(rows, jsonBookmarkImportRow{Title: b.DisplayName, URL: b.LinkUrl, Folder: b.Folder})
+	}
+
+	return json.MarshalIndent(rows, "", "  ")
+}
+
+// ImportChannelBookmarks bulk-creates link bookmarks on a channel from
+// either the JSON schema ExportChannelBookmarks produces or a Netscape
+// bookmarks HTML export (the format browsers and tools like Shiori use).
+// Rows whose URL is already bookmarked in the channel are skipped rather
+// than duplicated.
+func (a *App) ImportChannelBookmarks(channelId, userId string, data []byte, format string) (*model.BookmarkImportResult, error) {
+	var rows []bookmarkImportRow
+	var err error
+
+	switch format {
+	case BookmarkImportFormatJSON:
+		rows, err = parseJSONBookmarkImport(data)
+	case BookmarkImportFormatNetscape:
+		rows, err = parseNetscapeBookmarkImport(data)
+	default:
+		return nil, fmt.Errorf("unsupported bookmark import format %q", format)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	existingURLs, appErr := a.existingChannelBookmarkLinkURLs(channelId)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	result := &model.BookmarkImportResult{}
+
+	for _, row := range rows {
+		if row.URL == "" {
+			result.Skipped++
+			result.Errors = append(result.Errors, fmt.Sprintf("%q: missing URL", row.Title))
+			continue
+		}
+
+		if existingURLs[row.URL] {
+			result.Skipped++
+			result.Errors = append(result.Errors, fmt.Sprintf("%q: already bookmarked", row.Title))
+			continue
+		}
+
+		title := row.Title
+		if title == "" {
+			title = row.URL
+		}
+
+		bookmark := &model.ChannelBookmark{
+			ChannelId:   channelId,
+			DisplayName: title,
+			LinkUrl:     row.URL,
+			Type:        model.ChannelBookmarkLink,
+			Folder:      row.Folder,
+		}
+
+		if _, appErr := a.createImportedChannelBookmark(userId, bookmark); appErr != nil {
+			result.Skipped++
+			result.Errors = append(result.Errors, fmt.Sprintf("%q: %s", title, appErr.Error()))
+			continue
+		}
+
+		existingURLs[row.URL] = true
+		result.Imported++
+	}
+
+	return result, nil
+}
+
+func (a *App) existingChannelBookmarkLinkURLs(channelId string) (map[string]bool, *model.AppError) {
+	bookmarks, appErr := a.GetChannelBookmarks(channelId, 0)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	urls := make(map[string]bool, len(bookmarks))
+	for _, b := range bookmarks {
+		if b.Type == model.ChannelBookmarkLink {
+			urls[b.LinkUrl] = true
+		}
+	}
+
+	return urls, nil
+}
+
+func (a *App) createImportedChannelBookmark(userId string, bookmark *model.ChannelBookmark) (*model.ChannelBookmark, *model.AppError) {
+	bookmark.OwnerId = userId
+	bookmark.PreSave()
+
+	if appErr := bookmark.IsValid(); appErr != nil {
+		return nil, appErr
+	}
+
+	saved, err := a.Srv().Store().ChannelBookmark().Save(bookmark)
+	if err != nil {
+		return nil, model.NewAppError("ImportChannelBookmarks", "app.channel_bookmark.create.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	a.publishChannelBookmarkEvent(model.WebsocketEventChannelBookmarkCreated, &model.ChannelBookmarkWithFileInfo{ChannelBookmark: saved}, "")
+
+	return saved, nil
+}
+
+func parseJSONBookmarkImport(data []byte) ([]bookmarkImportRow, error) {
+	var raw []jsonBookmarkImportRow
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("invalid bookmark import JSON: %w", err)
+	}
+
+	rows := make([]bookmarkImportRow, 0, len(raw))
+	for _, r := range raw {
+		rows = append(rows, bookmarkImportRow{Title: r.Title, URL: r.URL, Folder: r.Folder})
+	}
+
+	return rows, nil
+}
+
+var (
+	netscapeFolderRe = regexp.MustCompile(`(?i)<H3[^>]*>(.*?)</H3>`)
+	netscapeLinkRe   = regexp.MustCompile(`(?i)<A\s+HREF="([^"]+)"[^>]*>(.*?)</A>`)
+)
+
+// parseNetscapeBookmarkImport walks a Netscape bookmarks HTML export
+// (<DL><DT><A HREF=...>Title</A>...</DL>) line by line, tracking the folder
+// stack formed by nested <DL>/<H3> pairs and mapping it onto each row's
+// Folder as a "/"-joined path.
+func parseNetscapeBookmarkImport(data []byte) ([]bookmarkImportRow, error) {
+	var rows []bookmarkImportRow
+	var folders []string
+
+	for _, line := range strings.Split(string(data), "\n") {
+		trimmed := strings.TrimSpace(line)
+		upper := strings.ToUpper(trimmed)
+
+		switch {
+		case strings.HasPrefix(upper, "<DL>"):
+			continue
+		case strings.HasPrefix(upper, "</DL>"):
+			if len(folders) > 0 {
+				folders = folders[:len(folders)-1]
+			}
+			continue
+		}
+
+		if m := netscapeFolderRe.FindStringSubmatch(trimmed); m != nil {
+			folders = append(folders, html.UnescapeString(m[1]))
+			continue
+		}
+
+		if m := netscapeLinkRe.FindStringSubmatch(trimmed); m != nil {
+			rows = append(rows, bookmarkImportRow{
+				URL:    html.UnescapeString(m[1]),
+				Title:  html.UnescapeString(m[2]),
+				Folder: strings.Join(folders, "/"),
+			})
+		}
+	}
+
+	return rows, nil
+}
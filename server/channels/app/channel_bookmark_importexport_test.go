@@ -0,0 +1,51 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseJSONBookmarkImport(t *testing.T) {
+	data := []byte(`[{"title":"Mattermost","url":"https://mattermost.com","folder":"Work"}]`)
+
+	rows, err := parseJSONBookmarkImport(data)
+	require.NoError(t, err)
+	require.Len(t, rows, 1)
+	assert.Equal(t, "Mattermost", rows[0].Title)
+	assert.Equal(t, "https://mattermost.com", rows[0].URL)
+	assert.Equal(t, "Work", rows[0].Folder)
+}
+
+func TestParseNetscapeBookmarkImport(t *testing.T) {
+	data := []byte(`<!DOCTYPE NETSCAPE-Bookmark-file-1>
+<DL><p>
+	<DT><A HREF="https://mattermost.com">Mattermost</A>
+	<DT><H3>Work</H3>
+	<DL><p>
+		<DT><A HREF="https://example.com">Example</A>
+	</DL><p>
+</DL><p>`)
+
+	rows, err := parseNetscapeBookmarkImport(data)
+	require.NoError(t, err)
+	require.Len(t, rows, 2)
+
+	assert.Equal(t, "https://mattermost.com", rows[0].URL)
+	assert.Equal(t, "", rows[0].Folder)
+
+	assert.Equal(t, "https://example.com", rows[1].URL)
+	assert.Equal(t, "Work", rows[1].Folder)
+}
+
+func TestImportChannelBookmarksUnsupportedFormat(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	_, err := th.App.ImportChannelBookmarks(th.BasicChannel.Id, th.BasicUser.Id, []byte(`[]`), "yaml")
+	require.Error(t, err)
+}
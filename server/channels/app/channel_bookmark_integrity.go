@@ -0,0 +1,83 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"net/http"
+
+	"github.com/mattermost/mattermost/server/public/model"
+)
+
+// PermanentDeleteFileInfo purges a file's metadata and underlying blob. It
+// refuses when the file is still referenced by a live ChannelBookmarkFile
+// bookmark rather than silently leaving that bookmark dangling.
+func (a *App) PermanentDeleteFileInfo(fileId string) *model.AppError {
+	referenced, err := a.Srv().Store().FileInfo().IsReferencedByBookmark(fileId)
+	if err != nil {
+		return model.NewAppError("PermanentDeleteFileInfo", "app.file_info.permanent_delete.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	if referenced {
+		return model.NewAppError("PermanentDeleteFileInfo", "app.file_info.permanent_delete.bookmarked.app_error", nil, "fileId="+fileId, http.StatusConflict)
+	}
+
+	if err := a.Srv().Store().FileInfo().PermanentDelete(fileId); err != nil {
+		return model.NewAppError("PermanentDeleteFileInfo", "app.file_info.permanent_delete.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	return nil
+}
+
+// PermanentDeleteFileInfoForce is the call site the file cleanup jobs (e.g.
+// an orphaned-upload sweep) use instead of PermanentDeleteFileInfo: rather
+// than refusing because a ChannelBookmarkFile still references the file, it
+// prunes that bookmark first so the delete can proceed without leaving a
+// dangling reference behind.
+func (a *App) PermanentDeleteFileInfoForce(fileId string) *model.AppError {
+	if _, appErr := a.pruneBookmarksForFile(fileId); appErr != nil {
+		return appErr
+	}
+
+	if err := a.Srv().Store().FileInfo().PermanentDelete(fileId); err != nil {
+		return model.NewAppError("PermanentDeleteFileInfoForce", "app.file_info.permanent_delete.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	return nil
+}
+
+// pruneBookmarksForFile soft-deletes any ChannelBookmarkFile bookmarks left
+// pointing at fileId, emitting channel_bookmark_deleted for each one. It's
+// called from the file cleanup jobs for files that were removed through a
+// path other than PermanentDeleteFileInfo (e.g. an orphan sweep), so
+// bookmarks don't end up referencing a blob that no longer exists.
+func (a *App) pruneBookmarksForFile(fileId string) ([]*model.ChannelBookmark, *model.AppError) {
+	pruned, err := a.Srv().Store().ChannelBookmark().PruneForFile(fileId)
+	if err != nil {
+		return nil, model.NewAppError("pruneBookmarksForFile", "app.channel_bookmark.prune.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	a.publishPrunedBookmarks(pruned)
+
+	return pruned, nil
+}
+
+// pruneBookmarksForPost soft-deletes any ChannelBookmarkFile bookmarks whose
+// underlying file belonged to postId, called when a post is permanently
+// deleted.
+func (a *App) pruneBookmarksForPost(postId string) ([]*model.ChannelBookmark, *model.AppError) {
+	pruned, err := a.Srv().Store().ChannelBookmark().PruneForPost(postId)
+	if err != nil {
+		return nil, model.NewAppError("pruneBookmarksForPost", "app.channel_bookmark.prune.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	a.publishPrunedBookmarks(pruned)
+
+	return pruned, nil
+}
+
+func (a *App) publishPrunedBookmarks(pruned []*model.ChannelBookmark) {
+	for _, bookmark := range pruned {
+		a.publishChannelBookmarkEvent(model.WebsocketEventChannelBookmarkDeleted, &model.ChannelBookmarkWithFileInfo{ChannelBookmark: bookmark}, "")
+	}
+}
@@ -0,0 +1,98 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPermanentDeleteFileInfo(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	th.Context.Session().UserId = th.BasicUser.Id
+
+	file := &model.FileInfo{Id: model.NewId(), CreatorId: th.BasicUser.Id, Path: "somepath"}
+	_, err := th.App.Srv().Store().FileInfo().Save(file)
+	require.NoError(t, err)
+
+	bookmark := &model.ChannelBookmark{
+		ChannelId:   th.BasicChannel.Id,
+		DisplayName: "File bookmark",
+		FileId:      file.Id,
+		Type:        model.ChannelBookmarkFile,
+	}
+	_, appErr := th.App.CreateChannelBookmark(th.Context, bookmark, "")
+	require.Nil(t, appErr)
+
+	t.Run("refuses to delete a file still referenced by a bookmark", func(t *testing.T) {
+		appErr := th.App.PermanentDeleteFileInfo(file.Id)
+		require.NotNil(t, appErr)
+		assert.Equal(t, http.StatusConflict, appErr.StatusCode)
+	})
+
+	t.Run("force-delete prunes the dangling bookmark first", func(t *testing.T) {
+		appErr := th.App.PermanentDeleteFileInfoForce(file.Id)
+		require.Nil(t, appErr)
+
+		updated, err := th.App.Srv().Store().ChannelBookmark().Get(bookmark.Id, true)
+		require.NoError(t, err)
+		assert.Greater(t, updated.DeleteAt, int64(0))
+	})
+}
+
+func TestPruneBookmarksForFile(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	th.Context.Session().UserId = th.BasicUser.Id
+
+	file := &model.FileInfo{Id: model.NewId(), CreatorId: th.BasicUser.Id, Path: "somepath"}
+	_, err := th.App.Srv().Store().FileInfo().Save(file)
+	require.NoError(t, err)
+
+	bookmark := &model.ChannelBookmark{
+		ChannelId:   th.BasicChannel.Id,
+		DisplayName: "File bookmark",
+		FileId:      file.Id,
+		Type:        model.ChannelBookmarkFile,
+	}
+	_, appErr := th.App.CreateChannelBookmark(th.Context, bookmark, "")
+	require.Nil(t, appErr)
+
+	pruned, appErr := th.App.pruneBookmarksForFile(file.Id)
+	require.Nil(t, appErr)
+	require.Len(t, pruned, 1)
+	assert.Equal(t, bookmark.Id, pruned[0].Id)
+}
+
+func TestPruneBookmarksForPost(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	th.Context.Session().UserId = th.BasicUser.Id
+
+	file := &model.FileInfo{Id: model.NewId(), CreatorId: th.BasicUser.Id, PostId: th.BasicPost.Id, Path: "somepath"}
+	_, err := th.App.Srv().Store().FileInfo().Save(file)
+	require.NoError(t, err)
+
+	bookmark := &model.ChannelBookmark{
+		ChannelId:   th.BasicChannel.Id,
+		DisplayName: "File bookmark",
+		FileId:      file.Id,
+		Type:        model.ChannelBookmarkFile,
+	}
+	_, appErr := th.App.CreateChannelBookmark(th.Context, bookmark, "")
+	require.Nil(t, appErr)
+
+	pruned, appErr := th.App.pruneBookmarksForPost(th.BasicPost.Id)
+	require.Nil(t, appErr)
+	require.Len(t, pruned, 1)
+	assert.Equal(t, bookmark.Id, pruned[0].Id)
+}
@@ -0,0 +1,137 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/mattermost/mattermost/server/public/model"
+)
+
+// rankAlphabet is the base62 alphabet used for lexicographic bookmark ranks,
+// ordered so byte comparison matches the intended sort order.
+const rankAlphabet = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"
+
+// rankSpacing controls how far apart initialRank spaces consecutive ranks,
+// leaving room to insert new bookmarks before/after without a rebalance.
+const rankSpacing = 4
+
+func rankDigit(c byte) int {
+	return strings.IndexByte(rankAlphabet, c)
+}
+
+// initialRank returns the rank for the index'th bookmark created in a fresh
+// channel (0-based), spaced out per rankSpacing.
+func initialRank(index int) string {
+	offset := (index + 1) * rankSpacing
+	if offset < len(rankAlphabet) {
+		return string(rankAlphabet[offset])
+	}
+
+	return string(rankAlphabet[len(rankAlphabet)-1]) + string(rankAlphabet[offset%len(rankAlphabet)])
+}
+
+// midpointRank returns a rank that sorts strictly between lo and hi. An
+// empty lo means "no lower bound" (move to the front), an empty hi means
+// "no upper bound" (move to the back). When lo and hi converge digit by
+// digit it extends the result with the middle character of the alphabet.
+func midpointRank(lo, hi string) string {
+	if lo != "" && hi != "" && lo >= hi {
+		hi = ""
+	}
+
+	var b strings.Builder
+	for i := 0; ; i++ {
+		loDigit := 0
+		if i < len(lo) {
+			loDigit = rankDigit(lo[i])
+		}
+
+		hiDigit := len(rankAlphabet) - 1
+		if hi != "" && i < len(hi) {
+			hiDigit = rankDigit(hi[i])
+		}
+
+		if hiDigit-loDigit > 1 {
+			b.WriteByte(rankAlphabet[loDigit+(hiDigit-loDigit)/2])
+			return b.String()
+		}
+
+		b.WriteByte(rankAlphabet[loDigit])
+
+		if i+1 >= len(lo) && (hi == "" || i+1 >= len(hi)) {
+			b.WriteByte(rankAlphabet[len(rankAlphabet)/2])
+			return b.String()
+		}
+	}
+}
+
+// rankNeedsRebalance flags ranks that have grown long enough to suggest
+// their neighbors have converged, so a background rebalance should
+// renormalize the channel's ranks.
+func rankNeedsRebalance(rank string) bool {
+	return len(rank) > 3
+}
+
+// UpdateChannelBookmarkRank moves a bookmark between afterId and beforeId
+// (either may be "" for "start"/"end" of the list), writing a single row
+// instead of renumbering every sibling. SortOrder is kept as a computed,
+// read-only view derived from rank position for backward compatibility.
+func (a *App) UpdateChannelBookmarkRank(bookmarkId, channelId, afterId, beforeId string) (*model.ChannelBookmarkWithFileInfo, *model.AppError) {
+	if _, err := a.Srv().Store().ChannelBookmark().Get(bookmarkId, false); err != nil {
+		return nil, model.NewAppError("UpdateChannelBookmarkRank", "app.channel_bookmark.sort_order.not_found.app_error", nil, "", http.StatusNotFound).Wrap(err)
+	}
+
+	afterRank, beforeRank, appErr := a.neighborRanks(afterId, beforeId)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	updated, err := a.Srv().Store().ChannelBookmark().UpdateRank(bookmarkId, afterRank, beforeRank)
+	if err != nil {
+		return nil, model.NewAppError("UpdateChannelBookmarkRank", "app.channel_bookmark.sort_order.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	if rankNeedsRebalance(updated.Rank) {
+		a.Srv().Go(func() {
+			a.RebalanceChannelBookmarkRanks(channelId)
+		})
+	}
+
+	result := &model.ChannelBookmarkWithFileInfo{ChannelBookmark: updated}
+	a.publishChannelBookmarkSortOrderEvent(channelId, []*model.ChannelBookmarkWithFileInfo{result}, "")
+
+	return result, nil
+}
+
+// RebalanceChannelBookmarkRanks renormalizes a channel's bookmark ranks back
+// to evenly-spaced values, without changing their relative order.
+func (a *App) RebalanceChannelBookmarkRanks(channelId string) *model.AppError {
+	if err := a.Srv().Store().ChannelBookmark().RebalanceRanks(channelId); err != nil {
+		return model.NewAppError("RebalanceChannelBookmarkRanks", "app.channel_bookmark.rebalance.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	return nil
+}
+
+func (a *App) neighborRanks(afterId, beforeId string) (afterRank, beforeRank string, appErr *model.AppError) {
+	if afterId != "" {
+		after, err := a.Srv().Store().ChannelBookmark().Get(afterId, false)
+		if err != nil {
+			return "", "", model.NewAppError("UpdateChannelBookmarkRank", "app.channel_bookmark.sort_order.not_found.app_error", nil, "", http.StatusNotFound).Wrap(err)
+		}
+		afterRank = after.Rank
+	}
+
+	if beforeId != "" {
+		before, err := a.Srv().Store().ChannelBookmark().Get(beforeId, false)
+		if err != nil {
+			return "", "", model.NewAppError("UpdateChannelBookmarkRank", "app.channel_bookmark.sort_order.not_found.app_error", nil, "", http.StatusNotFound).Wrap(err)
+		}
+		beforeRank = before.Rank
+	}
+
+	return afterRank, beforeRank, nil
+}
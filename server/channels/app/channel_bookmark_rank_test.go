@@ -0,0 +1,40 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMidpointRank(t *testing.T) {
+	t.Run("between distinct single-character ranks sorts in between", func(t *testing.T) {
+		mid := midpointRank("a", "c")
+		assert.Greater(t, mid, "a")
+		assert.Less(t, mid, "c")
+	})
+
+	t.Run("with no lower bound sorts before hi", func(t *testing.T) {
+		mid := midpointRank("", "a")
+		assert.Less(t, mid, "a")
+	})
+
+	t.Run("with no upper bound sorts after lo", func(t *testing.T) {
+		mid := midpointRank("a", "")
+		assert.Greater(t, mid, "a")
+	})
+
+	t.Run("adjacent ranks extend rather than collide", func(t *testing.T) {
+		mid := midpointRank("a", "b")
+		assert.Greater(t, mid, "a")
+		assert.Less(t, mid, "b")
+	})
+}
+
+func TestInitialRank(t *testing.T) {
+	first := initialRank(0)
+	second := initialRank(1)
+	assert.Less(t, first, second)
+}
@@ -0,0 +1,76 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"net/http"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/v8/channels/app/request"
+)
+
+const bookmarkSearchDefaultPerPage = 20
+
+// SearchChannelBookmarks performs a full-text search across channel
+// bookmarks, restricted to channels userId is a member of. The Postgres
+// tsvector / MySQL FULLTEXT index over DisplayName, LinkUrl, and (for file
+// bookmarks) the joined FileInfo.Name lives in the store layer; results stay
+// fresh because every create/update/delete already publishes a
+// channel_bookmark_* websocket event that tells clients to refresh.
+func (a *App) SearchChannelBookmarks(userId string, opts model.BookmarkSearchOptions) ([]*model.ChannelBookmarkWithFileInfo, *model.AppError) {
+	if opts.PerPage <= 0 || opts.PerPage > 100 {
+		opts.PerPage = bookmarkSearchDefaultPerPage
+	}
+
+	channelIds, appErr := a.filterChannelIdsByMembership(userId, opts.ChannelIds, opts.TeamId)
+	if appErr != nil {
+		return nil, appErr
+	}
+	opts.ChannelIds = channelIds
+
+	if len(opts.ChannelIds) == 0 {
+		return []*model.ChannelBookmarkWithFileInfo{}, nil
+	}
+
+	results, err := a.Srv().Store().ChannelBookmark().Search(opts)
+	if err != nil {
+		return nil, model.NewAppError("SearchChannelBookmarks", "app.channel_bookmark.search.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	return results, nil
+}
+
+// filterChannelIdsByMembership narrows channelIds down to the ones userId
+// actually belongs to, or (when none were requested) every channel of
+// teamId they belong to.
+func (a *App) filterChannelIdsByMembership(userId string, channelIds []string, teamId string) ([]string, *model.AppError) {
+	if len(channelIds) == 0 {
+		if teamId == "" {
+			return nil, model.NewAppError("SearchChannelBookmarks", "app.channel_bookmark.search.scope_required.app_error", nil, "", http.StatusBadRequest)
+		}
+
+		members, appErr := a.GetChannelMembersForUser(teamId, userId)
+		if appErr != nil {
+			return nil, appErr
+		}
+
+		ids := make([]string, 0, len(members))
+		for _, member := range members {
+			ids = append(ids, member.ChannelId)
+		}
+
+		return ids, nil
+	}
+
+	c := request.EmptyContext(a.Log())
+
+	allowed := make([]string, 0, len(channelIds))
+	for _, channelId := range channelIds {
+		if _, appErr := a.GetChannelMember(c, channelId, userId); appErr == nil {
+			allowed = append(allowed, channelId)
+		}
+	}
+
+	return allowed, nil
+}
@@ -0,0 +1,85 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSearchChannelBookmarks(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	th.Context.Session().UserId = th.BasicUser.Id
+
+	bookmark := &model.ChannelBookmark{
+		ChannelId:   th.BasicChannel.Id,
+		DisplayName: "Release notes",
+		LinkUrl:     "https://mattermost.com/release-notes",
+		Type:        model.ChannelBookmarkLink,
+	}
+	_, appErr := th.App.CreateChannelBookmark(th.Context, bookmark, "")
+	require.Nil(t, appErr)
+
+	t.Run("finds bookmarks in channels the caller belongs to", func(t *testing.T) {
+		results, appErr := th.App.SearchChannelBookmarks(th.BasicUser.Id, model.BookmarkSearchOptions{
+			Terms:      "release",
+			ChannelIds: []string{th.BasicChannel.Id},
+		})
+		require.Nil(t, appErr)
+		assert.Len(t, results, 1)
+	})
+
+	t.Run("drops channels the caller isn't a member of instead of searching them", func(t *testing.T) {
+		outsider := th.CreateUser()
+
+		results, appErr := th.App.SearchChannelBookmarks(outsider.Id, model.BookmarkSearchOptions{
+			Terms:      "release",
+			ChannelIds: []string{th.BasicChannel.Id},
+		})
+		require.Nil(t, appErr)
+		assert.Empty(t, results)
+	})
+
+	t.Run("errors when neither channel IDs nor a team are given", func(t *testing.T) {
+		_, appErr := th.App.SearchChannelBookmarks(th.BasicUser.Id, model.BookmarkSearchOptions{Terms: "release"})
+		require.NotNil(t, appErr)
+		assert.Equal(t, http.StatusBadRequest, appErr.StatusCode)
+	})
+}
+
+func TestFilterChannelIdsByMembership(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	t.Run("scopes to every channel of the team the caller belongs to", func(t *testing.T) {
+		ids, appErr := th.App.filterChannelIdsByMembership(th.BasicUser.Id, nil, th.BasicTeam.Id)
+		require.Nil(t, appErr)
+		assert.Contains(t, ids, th.BasicChannel.Id)
+	})
+
+	t.Run("errors when no channel IDs and no team are given", func(t *testing.T) {
+		_, appErr := th.App.filterChannelIdsByMembership(th.BasicUser.Id, nil, "")
+		require.NotNil(t, appErr)
+	})
+
+	t.Run("drops explicit channel IDs the caller isn't a member of", func(t *testing.T) {
+		outsider := th.CreateUser()
+
+		ids, appErr := th.App.filterChannelIdsByMembership(outsider.Id, []string{th.BasicChannel.Id}, "")
+		require.Nil(t, appErr)
+		assert.Empty(t, ids)
+	})
+
+	t.Run("keeps explicit channel IDs the caller is a member of", func(t *testing.T) {
+		ids, appErr := th.App.filterChannelIdsByMembership(th.BasicUser.Id, []string{th.BasicChannel.Id}, "")
+		require.Nil(t, appErr)
+		assert.Equal(t, []string{th.BasicChannel.Id}, ids)
+	})
+}
@@ -4,6 +4,7 @@
 package app
 
 import (
+	"fmt"
 	"testing"
 
 	"github.com/mattermost/mattermost/server/public/model"
@@ -355,6 +356,30 @@ func TestGetChannelBookmarks(t *testing.T) {
 	})
 }
 
+func TestGetChannelBookmarksExhaustsEveryPage(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	th.Context.Session().UserId = th.BasicUser.Id
+
+	total := channelBookmarkPageDefaultLimit + 5
+	for i := 0; i < total; i++ {
+		bookmark := &model.ChannelBookmark{
+			ChannelId:   th.BasicChannel.Id,
+			DisplayName: fmt.Sprintf("Bookmark %d", i),
+			LinkUrl:     "https://mattermost.com",
+			Type:        model.ChannelBookmarkLink,
+			Emoji:       ":smile:",
+		}
+		_, err := th.App.CreateChannelBookmark(th.Context, bookmark, "")
+		require.Nil(t, err)
+	}
+
+	bookmarks, err := th.App.GetChannelBookmarks(th.BasicChannel.Id, 0)
+	require.Nil(t, err)
+	assert.Len(t, bookmarks, total)
+}
+
 func TestUpdateChannelBookmarkSortOrder(t *testing.T) {
 	th := Setup(t).InitBasic()
 	defer th.TearDown()
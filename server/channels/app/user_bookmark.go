@@ -0,0 +1,171 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/mattermost/mattermost/server/channels/store"
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+	"github.com/mattermost/mattermost/server/v8/channels/app/request"
+)
+
+// SaveUserBookmark creates a private, per-user bookmark on a post. It is the
+// personal counterpart to CreateChannelBookmark: the result is never visible
+// to anyone other than the saving user. userId is taken from the caller
+// (never from bookmark.UserId) so one user can't bookmark a post on another
+// user's behalf.
+func (a *App) SaveUserBookmark(c request.CTX, userId string, bookmark *model.Bookmark) (*model.Bookmark, *model.AppError) {
+	post, appErr := a.GetSinglePost(c, bookmark.PostId, false)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	if _, appErr := a.GetChannelMember(c, post.ChannelId, userId); appErr != nil {
+		return nil, model.NewAppError("SaveUserBookmark", "app.bookmark.save.not_a_member.app_error", nil, "", http.StatusForbidden).Wrap(appErr)
+	}
+
+	bookmark.UserId = userId
+	bookmark.PreSave()
+
+	if appErr := bookmark.IsValid(); appErr != nil {
+		return nil, appErr
+	}
+
+	_, err := a.Srv().Store().UserBookmark().Get(userId, bookmark.PostId)
+	if err == nil {
+		return nil, model.NewAppError("SaveUserBookmark", "app.bookmark.save.conflict.app_error", nil, "", http.StatusConflict)
+	}
+	var notFound *store.ErrNotFound
+	if !errors.As(err, &notFound) {
+		return nil, model.NewAppError("SaveUserBookmark", "app.bookmark.save.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	saved, err := a.Srv().Store().UserBookmark().Save(bookmark)
+	if err != nil {
+		var conflict *store.ErrConflict
+		if errors.As(err, &conflict) {
+			return nil, model.NewAppError("SaveUserBookmark", "app.bookmark.save.conflict.app_error", nil, "", http.StatusConflict).Wrap(err)
+		}
+		return nil, model.NewAppError("SaveUserBookmark", "app.bookmark.save.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	a.publishUserBookmarkEvent(model.WebsocketEventBookmarkAdded, saved)
+
+	return saved, nil
+}
+
+// UpdateUserBookmark edits the comment/tags on the caller's existing
+// bookmark for a post. Unlike UpdateChannelBookmark there is no ownership
+// fork to worry about: a user bookmark only ever belongs to one user.
+func (a *App) UpdateUserBookmark(c request.CTX, userId string, bookmark *model.Bookmark) (*model.Bookmark, *model.AppError) {
+	existing, appErr := a.getUserBookmark(userId, bookmark.PostId)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	existing.Comment = bookmark.Comment
+	existing.Tags = bookmark.Tags
+	existing.PreUpdate()
+
+	if appErr := existing.IsValid(); appErr != nil {
+		return nil, appErr
+	}
+
+	updated, err := a.Srv().Store().UserBookmark().Update(existing)
+	if err != nil {
+		return nil, model.NewAppError("UpdateUserBookmark", "app.bookmark.update.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	a.publishUserBookmarkEvent(model.WebsocketEventBookmarkAdded, updated)
+
+	return updated, nil
+}
+
+// GetUserBookmarksForPosts returns the caller's bookmarks among postIds,
+// keyed by post ID, for decorating a list of posts without one store round
+// trip per post.
+func (a *App) GetUserBookmarksForPosts(c request.CTX, userId string, postIds []string) (map[string]*model.Bookmark, *model.AppError) {
+	bookmarks, err := a.Srv().Store().UserBookmark().GetByPostIds(userId, postIds)
+	if err != nil {
+		return nil, model.NewAppError("GetUserBookmarksForPosts", "app.bookmark.get_by_post_ids.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	byPostId := make(map[string]*model.Bookmark, len(bookmarks))
+	for _, bookmark := range bookmarks {
+		byPostId[bookmark.PostId] = bookmark
+	}
+
+	return byPostId, nil
+}
+
+// RemoveUserBookmark deletes the caller's bookmark on the given post, if any.
+func (a *App) RemoveUserBookmark(c request.CTX, userId, postId string) *model.AppError {
+	bookmark, appErr := a.getUserBookmark(userId, postId)
+	if appErr != nil {
+		return appErr
+	}
+
+	if err := a.Srv().Store().UserBookmark().Delete(userId, postId); err != nil {
+		return model.NewAppError("RemoveUserBookmark", "app.bookmark.delete.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	a.publishUserBookmarkEvent(model.WebsocketEventBookmarkRemoved, bookmark)
+
+	return nil
+}
+
+// ListUserBookmarks returns the caller's bookmarks, optionally filtered by
+// tag and/or scoped to a team or channel, most recently created first.
+func (a *App) ListUserBookmarks(c request.CTX, userId string, opts model.BookmarkListOptions) ([]*model.Bookmark, *model.AppError) {
+	if opts.PerPage <= 0 || opts.PerPage > 200 {
+		opts.PerPage = 60
+	}
+
+	bookmarks, err := a.Srv().Store().UserBookmark().List(userId, opts)
+	if err != nil {
+		return nil, model.NewAppError("ListUserBookmarks", "app.bookmark.list.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	return bookmarks, nil
+}
+
+// IsPostBookmarkedBy reports whether userId has a (non-deleted) bookmark on postId.
+func (a *App) IsPostBookmarkedBy(c request.CTX, userId, postId string) (bool, *model.AppError) {
+	bookmark, appErr := a.getUserBookmark(userId, postId)
+	if appErr != nil {
+		if appErr.StatusCode == http.StatusNotFound {
+			return false, nil
+		}
+		return false, appErr
+	}
+
+	return bookmark != nil, nil
+}
+
+func (a *App) getUserBookmark(userId, postId string) (*model.Bookmark, *model.AppError) {
+	bookmark, err := a.Srv().Store().UserBookmark().Get(userId, postId)
+	if err != nil {
+		var notFound *store.ErrNotFound
+		if errors.As(err, &notFound) {
+			return nil, model.NewAppError("getUserBookmark", "app.bookmark.get.not_found.app_error", nil, "", http.StatusNotFound).Wrap(err)
+		}
+		return nil, model.NewAppError("getUserBookmark", "app.bookmark.get.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	return bookmark, nil
+}
+
+func (a *App) publishUserBookmarkEvent(event string, bookmark *model.Bookmark) {
+	message := model.NewWebSocketEvent(event, "", "", bookmark.UserId, nil, "")
+	bookmarkJSON, err := json.Marshal(bookmark)
+	if err != nil {
+		mlog.Warn("Failed to encode bookmark to JSON", mlog.Err(err))
+	}
+	message.Add("bookmark", string(bookmarkJSON))
+	a.Publish(message)
+}
@@ -0,0 +1,208 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSaveUserBookmark(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	th.Context.Session().UserId = th.BasicUser.Id
+
+	t.Run("save a bookmark on a post", func(t *testing.T) {
+		bookmark := &model.Bookmark{
+			UserId:  th.BasicUser.Id,
+			PostId:  th.BasicPost.Id,
+			Comment: "read this later",
+			Tags:    []string{"todo"},
+		}
+
+		saved, err := th.App.SaveUserBookmark(th.Context, th.BasicUser.Id, bookmark)
+		require.Nil(t, err)
+		assert.NotEmpty(t, saved.Id)
+		assert.Equal(t, th.BasicPost.Id, saved.PostId)
+	})
+
+	t.Run("error when the post does not exist", func(t *testing.T) {
+		bookmark := &model.Bookmark{
+			UserId: th.BasicUser.Id,
+			PostId: model.NewId(),
+		}
+
+		_, err := th.App.SaveUserBookmark(th.Context, th.BasicUser.Id, bookmark)
+		require.NotNil(t, err)
+	})
+
+	t.Run("error when the caller isn't a member of the post's channel", func(t *testing.T) {
+		outsider := th.CreateUser()
+
+		bookmark := &model.Bookmark{
+			UserId: outsider.Id,
+			PostId: th.BasicPost.Id,
+		}
+
+		_, err := th.App.SaveUserBookmark(th.Context, outsider.Id, bookmark)
+		require.NotNil(t, err)
+		assert.Equal(t, http.StatusForbidden, err.StatusCode)
+	})
+
+	t.Run("error when the post is already bookmarked by the caller", func(t *testing.T) {
+		bookmark := &model.Bookmark{
+			UserId: th.BasicUser.Id,
+			PostId: th.BasicPost2.Id,
+		}
+
+		_, err := th.App.SaveUserBookmark(th.Context, th.BasicUser.Id, bookmark)
+		require.Nil(t, err)
+
+		_, err = th.App.SaveUserBookmark(th.Context, th.BasicUser.Id, &model.Bookmark{
+			UserId: th.BasicUser.Id,
+			PostId: th.BasicPost2.Id,
+		})
+		require.NotNil(t, err)
+		assert.Equal(t, http.StatusConflict, err.StatusCode)
+	})
+
+	t.Run("error when a tag contains a comma", func(t *testing.T) {
+		bookmark := &model.Bookmark{
+			UserId: th.BasicUser.Id,
+			PostId: th.BasicPost.Id,
+			Tags:   []string{"a,b"},
+		}
+
+		_, err := th.App.SaveUserBookmark(th.Context, th.BasicUser.Id, bookmark)
+		require.NotNil(t, err)
+	})
+}
+
+func TestRemoveUserBookmark(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	th.Context.Session().UserId = th.BasicUser.Id
+
+	bookmark := &model.Bookmark{
+		UserId: th.BasicUser.Id,
+		PostId: th.BasicPost.Id,
+	}
+
+	_, err := th.App.SaveUserBookmark(th.Context, th.BasicUser.Id, bookmark)
+	require.Nil(t, err)
+
+	t.Run("remove an existing bookmark", func(t *testing.T) {
+		err := th.App.RemoveUserBookmark(th.Context, th.BasicUser.Id, th.BasicPost.Id)
+		require.Nil(t, err)
+
+		bookmarked, err := th.App.IsPostBookmarkedBy(th.Context, th.BasicUser.Id, th.BasicPost.Id)
+		require.Nil(t, err)
+		assert.False(t, bookmarked)
+	})
+
+	t.Run("error when there is nothing to remove", func(t *testing.T) {
+		err := th.App.RemoveUserBookmark(th.Context, th.BasicUser.Id, th.BasicPost.Id)
+		require.NotNil(t, err)
+	})
+}
+
+func TestListUserBookmarks(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	th.Context.Session().UserId = th.BasicUser.Id
+
+	bookmark1 := &model.Bookmark{
+		UserId: th.BasicUser.Id,
+		PostId: th.BasicPost.Id,
+		Tags:   []string{"urgent"},
+	}
+	_, err := th.App.SaveUserBookmark(th.Context, th.BasicUser.Id, bookmark1)
+	require.Nil(t, err)
+
+	t.Run("list bookmarks for the caller", func(t *testing.T) {
+		bookmarks, err := th.App.ListUserBookmarks(th.Context, th.BasicUser.Id, model.BookmarkListOptions{})
+		require.Nil(t, err)
+		assert.Len(t, bookmarks, 1)
+	})
+
+	t.Run("list bookmarks filtered by tag", func(t *testing.T) {
+		bookmarks, err := th.App.ListUserBookmarks(th.Context, th.BasicUser.Id, model.BookmarkListOptions{Tag: "urgent"})
+		require.Nil(t, err)
+		assert.Len(t, bookmarks, 1)
+
+		bookmarks, err = th.App.ListUserBookmarks(th.Context, th.BasicUser.Id, model.BookmarkListOptions{Tag: "someone-else"})
+		require.Nil(t, err)
+		assert.Len(t, bookmarks, 0)
+	})
+}
+
+func TestUpdateUserBookmark(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	th.Context.Session().UserId = th.BasicUser.Id
+
+	_, err := th.App.SaveUserBookmark(th.Context, th.BasicUser.Id, &model.Bookmark{
+		UserId:  th.BasicUser.Id,
+		PostId:  th.BasicPost.Id,
+		Comment: "read this later",
+	})
+	require.Nil(t, err)
+
+	updated, err := th.App.UpdateUserBookmark(th.Context, th.BasicUser.Id, &model.Bookmark{
+		PostId:  th.BasicPost.Id,
+		Comment: "actually, already read it",
+		Tags:    []string{"done"},
+	})
+	require.Nil(t, err)
+	assert.Equal(t, "actually, already read it", updated.Comment)
+	assert.Equal(t, []string{"done"}, updated.Tags)
+}
+
+func TestGetUserBookmarksForPosts(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	th.Context.Session().UserId = th.BasicUser.Id
+
+	saved, err := th.App.SaveUserBookmark(th.Context, th.BasicUser.Id, &model.Bookmark{
+		UserId: th.BasicUser.Id,
+		PostId: th.BasicPost.Id,
+	})
+	require.Nil(t, err)
+
+	byPostId, err := th.App.GetUserBookmarksForPosts(th.Context, th.BasicUser.Id, []string{th.BasicPost.Id, th.BasicPost2.Id})
+	require.Nil(t, err)
+	require.Contains(t, byPostId, th.BasicPost.Id)
+	assert.Equal(t, saved.Id, byPostId[th.BasicPost.Id].Id)
+	assert.NotContains(t, byPostId, th.BasicPost2.Id)
+}
+
+func TestIsPostBookmarkedBy(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	th.Context.Session().UserId = th.BasicUser.Id
+
+	bookmarked, err := th.App.IsPostBookmarkedBy(th.Context, th.BasicUser.Id, th.BasicPost.Id)
+	require.Nil(t, err)
+	assert.False(t, bookmarked)
+
+	_, err = th.App.SaveUserBookmark(th.Context, th.BasicUser.Id, &model.Bookmark{
+		UserId: th.BasicUser.Id,
+		PostId: th.BasicPost.Id,
+	})
+	require.Nil(t, err)
+
+	bookmarked, err = th.App.IsPostBookmarkedBy(th.Context, th.BasicUser.Id, th.BasicPost.Id)
+	require.Nil(t, err)
+	assert.True(t, bookmarked)
+}
@@ -0,0 +1,36 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package store
+
+import (
+	"github.com/mattermost/mattermost/server/public/model"
+)
+
+// ChannelBookmarkStore persists channel-wide bookmarks (links or files)
+// pinned for every member of a channel to see.
+type ChannelBookmarkStore interface {
+	Save(bookmark *model.ChannelBookmark) (*model.ChannelBookmark, error)
+	Update(bookmark *model.ChannelBookmark) error
+	Get(id string, includeDeleted bool) (*model.ChannelBookmark, error)
+	Delete(id string) error
+
+	// GetBookmarksForChannelSince and GetBookmarksForChannelsSince back the
+	// legacy delta-sync API: since == 0 returns every live bookmark, since >
+	// 0 returns only rows touched (created, updated or soft-deleted) at or
+	// after that time.
+	GetBookmarksForChannelSince(channelId string, since int64) ([]*model.ChannelBookmarkWithFileInfo, error)
+	GetBookmarksForChannelsSince(channelIds []string, since int64) (map[string][]*model.ChannelBookmarkWithFileInfo, error)
+
+	// GetPage is the cursor-based primitive both of the above are built on.
+	GetPage(query model.ChannelBookmarkQuery) (*model.ChannelBookmarkPage, error)
+
+	UpdateSortOrder(bookmarkId, channelId string, newIndex int64) ([]*model.ChannelBookmark, error)
+	UpdateRank(bookmarkId, afterRank, beforeRank string) (*model.ChannelBookmark, error)
+	RebalanceRanks(channelId string) error
+
+	PruneForFile(fileId string) ([]*model.ChannelBookmark, error)
+	PruneForPost(postId string) ([]*model.ChannelBookmark, error)
+
+	Search(opts model.BookmarkSearchOptions) ([]*model.ChannelBookmarkWithFileInfo, error)
+}
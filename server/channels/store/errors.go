@@ -0,0 +1,67 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package store
+
+import (
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"net"
+)
+
+// ErrNotFound is returned by store methods when the requested row doesn't
+// exist (or is soft-deleted, for stores that treat that as "not found").
+type ErrNotFound struct {
+	resource string
+	id       string
+}
+
+func NewErrNotFound(resource, id string) *ErrNotFound {
+	return &ErrNotFound{resource: resource, id: id}
+}
+
+func (e *ErrNotFound) Error() string {
+	return fmt.Sprintf("%s not found: id=%s", e.resource, e.id)
+}
+
+// ErrConflict is returned when a write would violate a uniqueness
+// constraint (e.g. double-bookmarking the same post).
+type ErrConflict struct {
+	resource string
+	id       string
+	err      error
+}
+
+func NewErrConflict(resource string, err error, id string) *ErrConflict {
+	return &ErrConflict{resource: resource, id: id, err: err}
+}
+
+func (e *ErrConflict) Error() string {
+	return fmt.Sprintf("%s conflict: id=%s: %v", e.resource, e.id, e.err)
+}
+
+func (e *ErrConflict) Unwrap() error {
+	return e.err
+}
+
+// IsRetryableError reports whether err looks like a transient connectivity
+// problem (dropped connection, timeout) worth retrying, as opposed to a
+// data/logic error that will fail again no matter how many times it's
+// retried.
+func IsRetryableError(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	if errors.Is(err, driver.ErrBadConn) {
+		return true
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		return netErr.Timeout()
+	}
+
+	return false
+}
@@ -0,0 +1,23 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package store
+
+import (
+	"github.com/mattermost/mattermost/server/public/model"
+)
+
+// FileInfoStore persists metadata about uploaded files. Only the subset of
+// methods this tree's channel/user bookmark features depend on is declared
+// here; the full interface lives alongside the rest of the file upload
+// subsystem.
+type FileInfoStore interface {
+	Save(info *model.FileInfo) (*model.FileInfo, error)
+	Get(id string) (*model.FileInfo, error)
+	PermanentDelete(fileId string) error
+
+	// IsReferencedByBookmark reports whether fileId is still pointed to by a
+	// live ChannelBookmarkFile bookmark, so PermanentDeleteFileInfo can
+	// refuse to purge a blob that's still bookmarked.
+	IsReferencedByBookmark(fileId string) (bool, error)
+}
@@ -0,0 +1,60 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+// Package localcachelayer wraps a store.Store, caching the results of
+// frequently-read, rarely-changed queries in process memory. Bookmarks are
+// per-user and change on every save/remove, so UserBookmarkStore is a
+// passthrough here rather than a cached accessor; it still needs a layer
+// entry so callers that hold a *LocalCacheStore satisfy store.Store.
+// Regenerate with `make store-layers` once the wider store package exists
+// in this tree; this file only covers the bookmark slice added alongside
+// it for now.
+package localcachelayer
+
+import (
+	"github.com/mattermost/mattermost/server/channels/store"
+	"github.com/mattermost/mattermost/server/public/model"
+)
+
+type LocalCacheStore struct {
+	store.Store
+	userBookmarkStore LocalCacheUserBookmarkStore
+}
+
+func NewLocalCacheLayer(childStore store.Store) *LocalCacheStore {
+	layer := &LocalCacheStore{Store: childStore}
+	layer.userBookmarkStore = LocalCacheUserBookmarkStore{ChildStore: childStore.UserBookmark()}
+	return layer
+}
+
+func (s *LocalCacheStore) UserBookmark() store.UserBookmarkStore {
+	return s.userBookmarkStore
+}
+
+type LocalCacheUserBookmarkStore struct {
+	ChildStore store.UserBookmarkStore
+}
+
+func (s LocalCacheUserBookmarkStore) Save(bookmark *model.Bookmark) (*model.Bookmark, error) {
+	return s.ChildStore.Save(bookmark)
+}
+
+func (s LocalCacheUserBookmarkStore) Update(bookmark *model.Bookmark) (*model.Bookmark, error) {
+	return s.ChildStore.Update(bookmark)
+}
+
+func (s LocalCacheUserBookmarkStore) Delete(userId, postId string) error {
+	return s.ChildStore.Delete(userId, postId)
+}
+
+func (s LocalCacheUserBookmarkStore) Get(userId, postId string) (*model.Bookmark, error) {
+	return s.ChildStore.Get(userId, postId)
+}
+
+func (s LocalCacheUserBookmarkStore) GetByPostIds(userId string, postIds []string) ([]*model.Bookmark, error) {
+	return s.ChildStore.GetByPostIds(userId, postIds)
+}
+
+func (s LocalCacheUserBookmarkStore) List(userId string, opts model.BookmarkListOptions) ([]*model.Bookmark, error) {
+	return s.ChildStore.List(userId, opts)
+}
@@ -0,0 +1,79 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+// Package opentracinglayer wraps a store.Store, emitting a tracing span for
+// each call. Regenerate with `make store-layers` once the wider store
+// package exists in this tree; this file only covers the bookmark slice
+// added alongside it for now.
+package opentracinglayer
+
+import (
+	"context"
+
+	"github.com/opentracing/opentracing-go"
+
+	"github.com/mattermost/mattermost/server/channels/store"
+	"github.com/mattermost/mattermost/server/public/model"
+)
+
+type OpenTracingLayer struct {
+	store.Store
+	ctx               context.Context
+	userBookmarkStore OpenTracingLayerUserBookmarkStore
+}
+
+func New(childStore store.Store, ctx context.Context) *OpenTracingLayer {
+	layer := &OpenTracingLayer{Store: childStore, ctx: ctx}
+	layer.userBookmarkStore = OpenTracingLayerUserBookmarkStore{ChildStore: childStore.UserBookmark(), ctx: ctx}
+	return layer
+}
+
+func (s *OpenTracingLayer) UserBookmark() store.UserBookmarkStore {
+	return s.userBookmarkStore
+}
+
+type OpenTracingLayerUserBookmarkStore struct {
+	ChildStore store.UserBookmarkStore
+	ctx        context.Context
+}
+
+func (s OpenTracingLayerUserBookmarkStore) startSpan(name string) opentracing.Span {
+	span, _ := opentracing.StartSpanFromContext(s.ctx, name)
+	return span
+}
+
+func (s OpenTracingLayerUserBookmarkStore) Save(bookmark *model.Bookmark) (*model.Bookmark, error) {
+	span := s.startSpan("UserBookmarkStore.Save")
+	defer span.Finish()
+	return s.ChildStore.Save(bookmark)
+}
+
+func (s OpenTracingLayerUserBookmarkStore) Update(bookmark *model.Bookmark) (*model.Bookmark, error) {
+	span := s.startSpan("UserBookmarkStore.Update")
+	defer span.Finish()
+	return s.ChildStore.Update(bookmark)
+}
+
+func (s OpenTracingLayerUserBookmarkStore) Delete(userId, postId string) error {
+	span := s.startSpan("UserBookmarkStore.Delete")
+	defer span.Finish()
+	return s.ChildStore.Delete(userId, postId)
+}
+
+func (s OpenTracingLayerUserBookmarkStore) Get(userId, postId string) (*model.Bookmark, error) {
+	span := s.startSpan("UserBookmarkStore.Get")
+	defer span.Finish()
+	return s.ChildStore.Get(userId, postId)
+}
+
+func (s OpenTracingLayerUserBookmarkStore) GetByPostIds(userId string, postIds []string) ([]*model.Bookmark, error) {
+	span := s.startSpan("UserBookmarkStore.GetByPostIds")
+	defer span.Finish()
+	return s.ChildStore.GetByPostIds(userId, postIds)
+}
+
+func (s OpenTracingLayerUserBookmarkStore) List(userId string, opts model.BookmarkListOptions) ([]*model.Bookmark, error) {
+	span := s.startSpan("UserBookmarkStore.List")
+	defer span.Finish()
+	return s.ChildStore.List(userId, opts)
+}
@@ -0,0 +1,80 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+// Package retrylayer wraps a store.Store, retrying operations that fail on
+// a transient/retryable database error (e.g. a dropped connection).
+// Regenerate with `make store-layers` once the wider store package exists
+// in this tree; this file only covers the bookmark slice added alongside
+// it for now.
+package retrylayer
+
+import (
+	"time"
+
+	"github.com/mattermost/mattermost/server/channels/store"
+	"github.com/mattermost/mattermost/server/public/model"
+)
+
+const (
+	retryAttempts = 3
+	retryBackoff  = 100 * time.Millisecond
+)
+
+type RetryLayer struct {
+	store.Store
+	userBookmarkStore RetryLayerUserBookmarkStore
+}
+
+func New(childStore store.Store) *RetryLayer {
+	layer := &RetryLayer{Store: childStore}
+	layer.userBookmarkStore = RetryLayerUserBookmarkStore{ChildStore: childStore.UserBookmark()}
+	return layer
+}
+
+func (s *RetryLayer) UserBookmark() store.UserBookmarkStore {
+	return s.userBookmarkStore
+}
+
+type RetryLayerUserBookmarkStore struct {
+	ChildStore store.UserBookmarkStore
+}
+
+func withRetry[T any](fn func() (T, error)) (T, error) {
+	var result T
+	var err error
+
+	for attempt := 0; attempt < retryAttempts; attempt++ {
+		result, err = fn()
+		if err == nil || !store.IsRetryableError(err) {
+			return result, err
+		}
+		time.Sleep(retryBackoff * time.Duration(attempt+1))
+	}
+
+	return result, err
+}
+
+func (s RetryLayerUserBookmarkStore) Save(bookmark *model.Bookmark) (*model.Bookmark, error) {
+	return withRetry(func() (*model.Bookmark, error) { return s.ChildStore.Save(bookmark) })
+}
+
+func (s RetryLayerUserBookmarkStore) Update(bookmark *model.Bookmark) (*model.Bookmark, error) {
+	return withRetry(func() (*model.Bookmark, error) { return s.ChildStore.Update(bookmark) })
+}
+
+func (s RetryLayerUserBookmarkStore) Delete(userId, postId string) error {
+	_, err := withRetry(func() (struct{}, error) { return struct{}{}, s.ChildStore.Delete(userId, postId) })
+	return err
+}
+
+func (s RetryLayerUserBookmarkStore) Get(userId, postId string) (*model.Bookmark, error) {
+	return withRetry(func() (*model.Bookmark, error) { return s.ChildStore.Get(userId, postId) })
+}
+
+func (s RetryLayerUserBookmarkStore) GetByPostIds(userId string, postIds []string) ([]*model.Bookmark, error) {
+	return withRetry(func() ([]*model.Bookmark, error) { return s.ChildStore.GetByPostIds(userId, postIds) })
+}
+
+func (s RetryLayerUserBookmarkStore) List(userId string, opts model.BookmarkListOptions) ([]*model.Bookmark, error) {
+	return withRetry(func() ([]*model.Bookmark, error) { return s.ChildStore.List(userId, opts) })
+}
@@ -0,0 +1,79 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+// Package sqlstore is a trimmed-down slice of the real sqlstore package,
+// covering only what's needed to back store.UserBookmarkStore with a real
+// database in this snapshot of the tree. The full package (all the other
+// Sql*Store types, migrations runner, replica/master connection setup)
+// does not exist here.
+package sqlstore
+
+import (
+	"strings"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+// SqlStore holds the master/replica connections shared by every Sql*Store.
+// The real implementation also tracks driver name, replica lag settings,
+// and the migrations runner; this one only carries what UserBookmarkStore
+// needs.
+type SqlStore struct {
+	master   *sqlx.DB
+	replicas []*sqlx.DB
+}
+
+func (ss *SqlStore) GetMasterX() *sqlx.DB {
+	return ss.master
+}
+
+func (ss *SqlStore) GetReplicaX() *sqlx.DB {
+	if len(ss.replicas) == 0 {
+		return ss.master
+	}
+	return ss.replicas[0]
+}
+
+func (ss *SqlStore) getQueryBuilder() sq.StatementBuilderType {
+	return sq.StatementBuilder.PlaceholderFormat(sq.Dollar)
+}
+
+// IsUniqueConstraintError reports whether err is a uniqueness-violation
+// coming back from the database, and whether it matches one of the given
+// constraint/field names.
+func IsUniqueConstraintError(err error, indexName []string) bool {
+	if err == nil {
+		return false
+	}
+
+	var pqErr *pq.Error
+	unique := false
+	field := ""
+
+	if errAs(err, &pqErr) {
+		unique = pqErr.Code == "23505"
+		field = pqErr.Constraint
+	}
+
+	if !unique {
+		return false
+	}
+
+	for _, name := range indexName {
+		if strings.Contains(field, name) || strings.EqualFold(field, name) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func errAs(err error, target **pq.Error) bool {
+	if pe, ok := err.(*pq.Error); ok {
+		*target = pe
+		return true
+	}
+	return false
+}
@@ -0,0 +1,212 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package sqlstore
+
+import (
+	"database/sql"
+	"strings"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost/server/channels/store"
+	"github.com/mattermost/mattermost/server/public/model"
+)
+
+type SqlUserBookmarkStore struct {
+	*SqlStore
+}
+
+func newSqlUserBookmarkStore(sqlStore *SqlStore) store.UserBookmarkStore {
+	return &SqlUserBookmarkStore{sqlStore}
+}
+
+func (s SqlUserBookmarkStore) Save(bookmark *model.Bookmark) (*model.Bookmark, error) {
+	query := `INSERT INTO Bookmarks
+		(Id, UserId, PostId, Comment, Tags, CreateAt, UpdateAt, DeleteAt)
+		VALUES (:Id, :UserId, :PostId, :Comment, :Tags, :CreateAt, :UpdateAt, 0)`
+
+	if _, err := s.GetMasterX().NamedExec(query, bookmarkToRow(bookmark)); err != nil {
+		if IsUniqueConstraintError(err, []string{"UserId", "PostId", "bookmarks_userid_postid_key", "idx_bookmarks_userid_postid"}) {
+			return nil, store.NewErrConflict("Bookmark", err, bookmark.Id)
+		}
+		return nil, errors.Wrap(err, "failed to save Bookmark")
+	}
+
+	return bookmark, nil
+}
+
+func (s SqlUserBookmarkStore) Update(bookmark *model.Bookmark) (*model.Bookmark, error) {
+	query := `UPDATE Bookmarks SET Comment = :Comment, Tags = :Tags, UpdateAt = :UpdateAt
+		WHERE UserId = :UserId AND PostId = :PostId AND DeleteAt = 0`
+
+	result, err := s.GetMasterX().NamedExec(query, bookmarkToRow(bookmark))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to update Bookmark")
+	}
+
+	if rows, rErr := result.RowsAffected(); rErr == nil && rows == 0 {
+		return nil, store.NewErrNotFound("Bookmark", bookmark.PostId)
+	}
+
+	return bookmark, nil
+}
+
+func (s SqlUserBookmarkStore) Delete(userId, postId string) error {
+	result, err := s.GetMasterX().Exec(
+		`UPDATE Bookmarks SET DeleteAt = ? WHERE UserId = ? AND PostId = ? AND DeleteAt = 0`,
+		model.GetMillis(), userId, postId,
+	)
+	if err != nil {
+		return errors.Wrap(err, "failed to delete Bookmark")
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return errors.Wrap(err, "failed to delete Bookmark")
+	}
+	if rows == 0 {
+		return store.NewErrNotFound("Bookmark", postId)
+	}
+
+	return nil
+}
+
+func (s SqlUserBookmarkStore) Get(userId, postId string) (*model.Bookmark, error) {
+	var row bookmarkRow
+	err := s.GetReplicaX().Get(&row,
+		`SELECT * FROM Bookmarks WHERE UserId = ? AND PostId = ? AND DeleteAt = 0`,
+		userId, postId,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, store.NewErrNotFound("Bookmark", postId)
+		}
+		return nil, errors.Wrap(err, "failed to get Bookmark")
+	}
+
+	return row.toModel(), nil
+}
+
+func (s SqlUserBookmarkStore) GetByPostIds(userId string, postIds []string) ([]*model.Bookmark, error) {
+	if len(postIds) == 0 {
+		return nil, nil
+	}
+
+	query, args, err := sqlx.In(
+		`SELECT * FROM Bookmarks WHERE UserId = ? AND PostId IN (?) AND DeleteAt = 0`,
+		userId, postIds,
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to build Bookmark query")
+	}
+
+	var rows []bookmarkRow
+	if err := s.GetReplicaX().Select(&rows, s.GetReplicaX().Rebind(query), args...); err != nil {
+		return nil, errors.Wrap(err, "failed to get Bookmarks")
+	}
+
+	bookmarks := make([]*model.Bookmark, 0, len(rows))
+	for _, row := range rows {
+		bookmarks = append(bookmarks, row.toModel())
+	}
+
+	return bookmarks, nil
+}
+
+func (s SqlUserBookmarkStore) List(userId string, opts model.BookmarkListOptions) ([]*model.Bookmark, error) {
+	builder := s.getQueryBuilder().
+		Select("Bookmarks.*").
+		From("Bookmarks").
+		Where(sq.Eq{"Bookmarks.UserId": userId, "Bookmarks.DeleteAt": 0}).
+		OrderBy("Bookmarks.CreateAt DESC")
+
+	if opts.Tag != "" {
+		escaped := strings.NewReplacer("\\", "\\\\", "%", "\\%", "_", "\\_").Replace(opts.Tag)
+		builder = builder.Where(sq.Like{"Bookmarks.Tags": "%," + escaped + ",%"})
+	}
+
+	if opts.ChannelId != "" {
+		builder = builder.
+			Join("Posts ON Posts.Id = Bookmarks.PostId").
+			Where(sq.Eq{"Posts.ChannelId": opts.ChannelId})
+	} else if opts.TeamId != "" {
+		builder = builder.
+			Join("Posts ON Posts.Id = Bookmarks.PostId").
+			Join("Channels ON Channels.Id = Posts.ChannelId").
+			Where(sq.Eq{"Channels.TeamId": opts.TeamId})
+	}
+
+	if opts.PerPage > 0 {
+		builder = builder.Limit(uint64(opts.PerPage)).Offset(uint64(opts.Page * opts.PerPage))
+	}
+
+	query, args, err := builder.ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to build Bookmark query")
+	}
+
+	var rows []bookmarkRow
+	if err := s.GetReplicaX().Select(&rows, query, args...); err != nil {
+		return nil, errors.Wrap(err, "failed to get Bookmarks")
+	}
+
+	bookmarks := make([]*model.Bookmark, 0, len(rows))
+	for _, row := range rows {
+		bookmarks = append(bookmarks, row.toModel())
+	}
+
+	return bookmarks, nil
+}
+
+// bookmarkRow is the flat shape Bookmarks is stored as; model.Bookmark keeps
+// Tags as a []string for API consumers. Tags is stored comma-joined *and*
+// comma-padded (",foo,bar,") rather than bare ("foo,bar") so the Tag filter
+// in List can anchor its LIKE on both sides of a tag and not match a
+// differently-named tag that merely contains the filter as a substring
+// (e.g. filtering by "foo" matching a tag "foobar"). Bookmark.IsValid
+// rejects commas in tag values, so this round-trips safely.
+type bookmarkRow struct {
+	Id       string
+	UserId   string
+	PostId   string
+	Comment  string
+	Tags     string
+	CreateAt int64
+	UpdateAt int64
+	DeleteAt int64
+}
+
+func bookmarkToRow(b *model.Bookmark) *bookmarkRow {
+	row := &bookmarkRow{
+		Id:       b.Id,
+		UserId:   b.UserId,
+		PostId:   b.PostId,
+		Comment:  b.Comment,
+		CreateAt: b.CreateAt,
+		UpdateAt: b.UpdateAt,
+		DeleteAt: b.DeleteAt,
+	}
+	if len(b.Tags) > 0 {
+		row.Tags = "," + strings.Join(b.Tags, ",") + ","
+	}
+	return row
+}
+
+func (r *bookmarkRow) toModel() *model.Bookmark {
+	b := &model.Bookmark{
+		Id:       r.Id,
+		UserId:   r.UserId,
+		PostId:   r.PostId,
+		Comment:  r.Comment,
+		CreateAt: r.CreateAt,
+		UpdateAt: r.UpdateAt,
+		DeleteAt: r.DeleteAt,
+	}
+	if trimmed := strings.Trim(r.Tags, ","); trimmed != "" {
+		b.Tags = strings.Split(trimmed, ",")
+	}
+	return b
+}
@@ -0,0 +1,36 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package sqlstore
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/mattermost/mattermost/server/public/model"
+)
+
+func TestBookmarkRowTagRoundTrip(t *testing.T) {
+	t.Run("tags round-trip through the comma-padded column", func(t *testing.T) {
+		b := &model.Bookmark{Tags: []string{"todo", "urgent"}}
+
+		row := bookmarkToRow(b)
+		assert.Equal(t, ",todo,urgent,", row.Tags)
+
+		back := row.toModel()
+		assert.Equal(t, []string{"todo", "urgent"}, back.Tags)
+	})
+
+	t.Run("no tags round-trips to an empty slice", func(t *testing.T) {
+		row := bookmarkToRow(&model.Bookmark{})
+		assert.Empty(t, row.Tags)
+		assert.Empty(t, row.toModel().Tags)
+	})
+
+	t.Run("padding anchors a substring tag filter", func(t *testing.T) {
+		row := bookmarkToRow(&model.Bookmark{Tags: []string{"foobar"}})
+		assert.NotContains(t, row.Tags, ",foo,")
+		assert.Contains(t, row.Tags, ",foobar,")
+	})
+}
@@ -0,0 +1,14 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package store
+
+// Store is the facade over every persistence-layer sub-store. Only the
+// accessors the bookmark features in this package depend on are declared
+// here; the full interface carries dozens more (User, Channel, Post, ...)
+// defined alongside those subsystems.
+type Store interface {
+	FileInfo() FileInfoStore
+	ChannelBookmark() ChannelBookmarkStore
+	UserBookmark() UserBookmarkStore
+}
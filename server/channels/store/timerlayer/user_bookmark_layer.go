@@ -0,0 +1,69 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+// Package timerlayer wraps a store.Store, recording how long each call
+// takes. Regenerate with `make store-layers` once the wider store package
+// exists in this tree; this file only covers the bookmark slice added
+// alongside it for now.
+package timerlayer
+
+import (
+	"time"
+
+	"github.com/mattermost/mattermost/server/channels/store"
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+)
+
+type TimerLayer struct {
+	store.Store
+	userBookmarkStore TimerLayerUserBookmarkStore
+}
+
+func New(childStore store.Store) *TimerLayer {
+	layer := &TimerLayer{Store: childStore}
+	layer.userBookmarkStore = TimerLayerUserBookmarkStore{ChildStore: childStore.UserBookmark()}
+	return layer
+}
+
+func (s *TimerLayer) UserBookmark() store.UserBookmarkStore {
+	return s.userBookmarkStore
+}
+
+type TimerLayerUserBookmarkStore struct {
+	ChildStore store.UserBookmarkStore
+}
+
+func recordDuration(method string, start time.Time) {
+	mlog.Debug("store call", mlog.String("method", method), mlog.Duration("duration", time.Since(start)))
+}
+
+func (s TimerLayerUserBookmarkStore) Save(bookmark *model.Bookmark) (*model.Bookmark, error) {
+	defer recordDuration("UserBookmarkStore.Save", time.Now())
+	return s.ChildStore.Save(bookmark)
+}
+
+func (s TimerLayerUserBookmarkStore) Update(bookmark *model.Bookmark) (*model.Bookmark, error) {
+	defer recordDuration("UserBookmarkStore.Update", time.Now())
+	return s.ChildStore.Update(bookmark)
+}
+
+func (s TimerLayerUserBookmarkStore) Delete(userId, postId string) error {
+	defer recordDuration("UserBookmarkStore.Delete", time.Now())
+	return s.ChildStore.Delete(userId, postId)
+}
+
+func (s TimerLayerUserBookmarkStore) Get(userId, postId string) (*model.Bookmark, error) {
+	defer recordDuration("UserBookmarkStore.Get", time.Now())
+	return s.ChildStore.Get(userId, postId)
+}
+
+func (s TimerLayerUserBookmarkStore) GetByPostIds(userId string, postIds []string) ([]*model.Bookmark, error) {
+	defer recordDuration("UserBookmarkStore.GetByPostIds", time.Now())
+	return s.ChildStore.GetByPostIds(userId, postIds)
+}
+
+func (s TimerLayerUserBookmarkStore) List(userId string, opts model.BookmarkListOptions) ([]*model.Bookmark, error) {
+	defer recordDuration("UserBookmarkStore.List", time.Now())
+	return s.ChildStore.List(userId, opts)
+}
@@ -0,0 +1,20 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package store
+
+import (
+	"github.com/mattermost/mattermost/server/public/model"
+)
+
+// UserBookmarkStore persists the per-user "save for later" bookmarks that
+// complement the channel-wide ChannelBookmarkStore. Rows are unique on
+// (user_id, post_id): a user can only bookmark a given post once.
+type UserBookmarkStore interface {
+	Save(bookmark *model.Bookmark) (*model.Bookmark, error)
+	Update(bookmark *model.Bookmark) (*model.Bookmark, error)
+	Delete(userId, postId string) error
+	Get(userId, postId string) (*model.Bookmark, error)
+	GetByPostIds(userId string, postIds []string) ([]*model.Bookmark, error)
+	List(userId string, opts model.BookmarkListOptions) ([]*model.Bookmark, error)
+}
@@ -0,0 +1,112 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	BookmarkCommentMaxRunes = 2000
+	BookmarkTagMaxRunes     = 64
+	BookmarkTagsMaxCount    = 20
+)
+
+const (
+	WebsocketEventBookmarkAdded   = "bookmark_added"
+	WebsocketEventBookmarkRemoved = "bookmark_removed"
+)
+
+// Bookmark is a private, per-user saved reference to a post, similar in
+// spirit to ChannelBookmark but scoped to the saving user rather than the
+// channel. Unlike a ChannelBookmark it is never shared with other members.
+type Bookmark struct {
+	Id       string   `json:"id"`
+	UserId   string   `json:"user_id"`
+	PostId   string   `json:"post_id"`
+	Comment  string   `json:"comment"`
+	Tags     []string `json:"tags"`
+	CreateAt int64    `json:"create_at"`
+	UpdateAt int64    `json:"update_at"`
+	DeleteAt int64    `json:"delete_at"`
+}
+
+func (b *Bookmark) Auditable() map[string]any {
+	return map[string]any{
+		"id":        b.Id,
+		"user_id":   b.UserId,
+		"post_id":   b.PostId,
+		"tags":      b.Tags,
+		"create_at": b.CreateAt,
+		"update_at": b.UpdateAt,
+		"delete_at": b.DeleteAt,
+	}
+}
+
+func (b *Bookmark) Clone() *Bookmark {
+	copy := *b
+	copy.Tags = append([]string(nil), b.Tags...)
+	return &copy
+}
+
+func (b *Bookmark) PreSave() {
+	if b.Id == "" {
+		b.Id = NewId()
+	}
+
+	b.CreateAt = GetMillis()
+	b.UpdateAt = b.CreateAt
+}
+
+func (b *Bookmark) PreUpdate() {
+	b.UpdateAt = GetMillis()
+}
+
+func (b *Bookmark) IsValid() *AppError {
+	if !IsValidId(b.Id) {
+		return NewAppError("Bookmark.IsValid", "model.bookmark.is_valid.id.app_error", nil, "id="+b.Id, 400)
+	}
+
+	if !IsValidId(b.UserId) {
+		return NewAppError("Bookmark.IsValid", "model.bookmark.is_valid.user_id.app_error", nil, "id="+b.Id, 400)
+	}
+
+	if !IsValidId(b.PostId) {
+		return NewAppError("Bookmark.IsValid", "model.bookmark.is_valid.post_id.app_error", nil, "id="+b.Id, 400)
+	}
+
+	if utf8.RuneCountInString(b.Comment) > BookmarkCommentMaxRunes {
+		return NewAppError("Bookmark.IsValid", "model.bookmark.is_valid.comment.app_error", nil, "id="+b.Id, 400)
+	}
+
+	if len(b.Tags) > BookmarkTagsMaxCount {
+		return NewAppError("Bookmark.IsValid", "model.bookmark.is_valid.tags_count.app_error", nil, "id="+b.Id, 400)
+	}
+
+	for _, tag := range b.Tags {
+		if tag == "" || utf8.RuneCountInString(tag) > BookmarkTagMaxRunes || strings.Contains(tag, ",") {
+			return NewAppError("Bookmark.IsValid", "model.bookmark.is_valid.tag.app_error", nil, "id="+b.Id, 400)
+		}
+	}
+
+	if b.CreateAt == 0 {
+		return NewAppError("Bookmark.IsValid", "model.bookmark.is_valid.create_at.app_error", nil, "id="+b.Id, 400)
+	}
+
+	if b.UpdateAt == 0 {
+		return NewAppError("Bookmark.IsValid", "model.bookmark.is_valid.update_at.app_error", nil, "id="+b.Id, 400)
+	}
+
+	return nil
+}
+
+// BookmarkListOptions controls paging/filtering for ListUserBookmarks.
+type BookmarkListOptions struct {
+	TeamId    string
+	ChannelId string
+	Tag       string
+	Page      int
+	PerPage   int
+}
@@ -0,0 +1,182 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+const (
+	ChannelBookmarkLink ChannelBookmarkType = "link"
+	ChannelBookmarkFile ChannelBookmarkType = "file"
+)
+
+const (
+	WebsocketEventChannelBookmarkCreated = "channel_bookmark_created"
+	WebsocketEventChannelBookmarkUpdated = "channel_bookmark_updated"
+	WebsocketEventChannelBookmarkDeleted = "channel_bookmark_deleted"
+	WebsocketEventChannelBookmarkSorted  = "channel_bookmark_sorted"
+)
+
+type ChannelBookmarkType string
+
+// ChannelBookmark is a channel-wide pinned reference to either an external
+// link or an uploaded file, surfaced to every member of the channel.
+type ChannelBookmark struct {
+	Id           string              `json:"id"`
+	CreateAt     int64               `json:"create_at"`
+	UpdateAt     int64               `json:"update_at"`
+	DeleteAt     int64               `json:"delete_at"`
+	ChannelId    string              `json:"channel_id"`
+	OwnerId      string              `json:"owner_id"`
+	OriginalId   string              `json:"original_id,omitempty"`
+	FileId       string              `json:"file_id,omitempty"`
+	DisplayName  string              `json:"display_name"`
+	SortOrder    int64               `json:"sort_order"`
+	Rank         string              `json:"-"`
+	LinkUrl      string              `json:"link_url,omitempty"`
+	ImageUrl     string              `json:"image_url,omitempty"`
+	Emoji        string              `json:"emoji,omitempty"`
+	Type         ChannelBookmarkType `json:"type"`
+	Folder       string              `json:"folder,omitempty"`
+	LinkMetadata *LinkMetadata       `json:"link_metadata,omitempty"`
+}
+
+// ChannelBookmarkWithFileInfo is what's returned to clients: the bookmark
+// plus the FileInfo it points to, when it is a file bookmark.
+type ChannelBookmarkWithFileInfo struct {
+	*ChannelBookmark
+	FileInfo *FileInfo `json:"file,omitempty"`
+
+	// Snippet is only populated by SearchChannelBookmarks: a highlighted
+	// excerpt around the matched terms, consistent with SearchPosts.
+	Snippet string `json:"snippet,omitempty"`
+}
+
+// UpdateChannelBookmarkResponse reports the result of UpdateChannelBookmark.
+// When someone other than the owner edits a bookmark, the original is
+// soft-deleted and a new one is forked under the editor's ownership; both
+// sides are reported so clients can reconcile their local state.
+type UpdateChannelBookmarkResponse struct {
+	Updated *ChannelBookmarkWithFileInfo `json:"updated"`
+	Deleted *ChannelBookmarkWithFileInfo `json:"deleted,omitempty"`
+}
+
+func (b *ChannelBookmark) Clone() *ChannelBookmark {
+	copy := *b
+	if b.LinkMetadata != nil {
+		lm := *b.LinkMetadata
+		copy.LinkMetadata = &lm
+	}
+	return &copy
+}
+
+func (b *ChannelBookmarkWithFileInfo) Clone() *ChannelBookmarkWithFileInfo {
+	copy := *b
+	copy.ChannelBookmark = b.ChannelBookmark.Clone()
+	return &copy
+}
+
+func (b *ChannelBookmark) PreSave() {
+	if b.Id == "" {
+		b.Id = NewId()
+	}
+
+	b.CreateAt = GetMillis()
+	b.UpdateAt = b.CreateAt
+	b.DeleteAt = 0
+}
+
+func (b *ChannelBookmark) PreUpdate() {
+	b.UpdateAt = GetMillis()
+}
+
+func (b *ChannelBookmark) IsValid() *AppError {
+	if !IsValidId(b.Id) {
+		return NewAppError("ChannelBookmark.IsValid", "model.channel_bookmark.is_valid.id.app_error", nil, "id="+b.Id, 400)
+	}
+
+	if !IsValidId(b.ChannelId) {
+		return NewAppError("ChannelBookmark.IsValid", "model.channel_bookmark.is_valid.channel_id.app_error", nil, "id="+b.Id, 400)
+	}
+
+	if !IsValidId(b.OwnerId) {
+		return NewAppError("ChannelBookmark.IsValid", "model.channel_bookmark.is_valid.owner_id.app_error", nil, "id="+b.Id, 400)
+	}
+
+	if b.DisplayName == "" {
+		return NewAppError("ChannelBookmark.IsValid", "model.channel_bookmark.is_valid.display_name.app_error", nil, "id="+b.Id, 400)
+	}
+
+	switch b.Type {
+	case ChannelBookmarkLink:
+		if b.LinkUrl == "" {
+			return NewAppError("ChannelBookmark.IsValid", "model.channel_bookmark.is_valid.link_url.app_error", nil, "id="+b.Id, 400)
+		}
+	case ChannelBookmarkFile:
+		if !IsValidId(b.FileId) {
+			return NewAppError("ChannelBookmark.IsValid", "model.channel_bookmark.is_valid.file_id.app_error", nil, "id="+b.Id, 400)
+		}
+	default:
+		return NewAppError("ChannelBookmark.IsValid", "model.channel_bookmark.is_valid.type.app_error", nil, "id="+b.Id, 400)
+	}
+
+	if b.CreateAt == 0 {
+		return NewAppError("ChannelBookmark.IsValid", "model.channel_bookmark.is_valid.create_at.app_error", nil, "id="+b.Id, 400)
+	}
+
+	if b.UpdateAt == 0 {
+		return NewAppError("ChannelBookmark.IsValid", "model.channel_bookmark.is_valid.update_at.app_error", nil, "id="+b.Id, 400)
+	}
+
+	return nil
+}
+
+// ChannelBookmarkQuery is the cursor-based query accepted by
+// App.GetChannelBookmarksPage. Cursor resumes a prior page, Since asks for
+// everything touched after a point in time (used by the legacy
+// GetChannelBookmarks/GetAllChannelBookmarks delta-sync wrappers). The two
+// can be combined: GetChannelBookmarks sets both while paging through a delta.
+type ChannelBookmarkQuery struct {
+	ChannelId      string
+	Since          int64
+	Cursor         string
+	Limit          int
+	IncludeDeleted bool
+}
+
+// ChannelBookmarkPage is a page of results ordered by (sort_order, id),
+// along with an opaque cursor to fetch the next page.
+type ChannelBookmarkPage struct {
+	Items      []*ChannelBookmarkWithFileInfo `json:"items"`
+	NextCursor string                         `json:"next_cursor,omitempty"`
+	HasMore    bool                           `json:"has_more"`
+}
+
+// LinkMetadata holds the OpenGraph/oEmbed data resolved for a
+// ChannelBookmarkLink bookmark by the async enrichment pipeline.
+type LinkMetadata struct {
+	Title        string `json:"title,omitempty"`
+	Description  string `json:"description,omitempty"`
+	FaviconUrl   string `json:"favicon_url,omitempty"`
+	CanonicalUrl string `json:"canonical_url,omitempty"`
+}
+
+// BookmarkImportResult reports the outcome of ImportChannelBookmarks: how
+// many rows were imported versus skipped (duplicates, unparseable entries),
+// with a human-readable reason for each skip.
+type BookmarkImportResult struct {
+	Imported int      `json:"imported"`
+	Skipped  int      `json:"skipped"`
+	Errors   []string `json:"errors,omitempty"`
+}
+
+// BookmarkSearchOptions scopes App.SearchChannelBookmarks.
+type BookmarkSearchOptions struct {
+	Terms          string
+	TeamId         string
+	ChannelIds     []string
+	Types          []ChannelBookmarkType
+	CreatedAfter   int64
+	CreatedBefore  int64
+	IncludeDeleted bool
+	Page           int
+	PerPage        int
+}